@@ -2,11 +2,17 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"log"
 	"log/slog"
 	"os"
 
 	"github.com/Kavantix/kantui/internal/app"
+	"github.com/Kavantix/kantui/internal/cli"
 	"github.com/Kavantix/kantui/internal/flags"
+	"github.com/Kavantix/kantui/internal/keys"
+	"github.com/Kavantix/kantui/internal/theme"
+	"github.com/Kavantix/kantui/internal/ticket"
 	tea "github.com/charmbracelet/bubbletea"
 	zone "github.com/lrstanley/bubblezone"
 )
@@ -15,13 +21,11 @@ func main() {
 	zone.NewGlobal()
 	defer zone.Close()
 
-	flags := flags.New()
-
-	program := tea.NewProgram(
-		app.New(flags),
-		tea.WithAltScreen(),
-		tea.WithMouseAllMotion(),
-	)
+	flags, err := flags.New()
+	if err != nil {
+		fmt.Println("error:", err)
+		os.Exit(1)
+	}
 
 	if flags.Debug() {
 		f, err := tea.LogToFile("debug.log", "debug")
@@ -30,11 +34,52 @@ func main() {
 			os.Exit(1)
 		}
 		defer f.Close()
+	} else {
+		// Logging to the terminal would corrupt the board
+		log.SetOutput(io.Discard)
+	}
+
+	// The store enforces the limits for the commands as well, like the api
+	wipLimits, err := ticket.ResolveWipLimits(flags.WipLimits())
+	if err != nil {
+		fmt.Println("error:", err)
+		os.Exit(1)
+	}
+	ticket.SetWipLimits(wipLimits)
+
+	if len(flags.Args()) > 0 {
+		if err := cli.Run(flags); err != nil {
+			fmt.Println("error:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// The commands do not use keybindings, so an invalid keymap only affects the board
+	keymap, err := keys.Load(flags.Keymap())
+	if err != nil {
+		fmt.Println("error:", err)
+		os.Exit(1)
+	}
+	keys.Set(keymap)
+
+	// The background of the terminal can not be detected once the program is running
+	colors, err := theme.Resolve(flags.Theme(), flags.Colors())
+	if err != nil {
+		fmt.Println("error:", err)
+		os.Exit(1)
+	}
+	theme.Set(colors)
+
+	options := []tea.ProgramOption{tea.WithAltScreen()}
+	if flags.Mouse() {
+		options = append(options, tea.WithMouseAllMotion())
 	}
+	program := tea.NewProgram(app.New(flags), options...)
 
 	slog.Info("Starting")
 
-	_, err := program.Run()
+	_, err = program.Run()
 	if err != nil {
 		slog.Error("Running program failed: ", slog.String("error", err.Error()))
 	}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"github.com/Kavantix/kantui/internal/ticket"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+)
+
+const (
+	minDetailsWidth  = 30
+	maxDetailsWidth  = 60
+	minDetailsHeight = 8
+)
+
+const detailsZone = "details"
+
+// detailsWidth is the width of the details on the right of the board, zero when they are not shown there
+func (m Model) detailsWidth() int {
+	if !m.showDetails || m.detailsBelow {
+		return 0
+	}
+	return min(max(minDetailsWidth, m.windowWidth/3), maxDetailsWidth, m.windowWidth/2)
+}
+
+// detailsHeight is the height of the details below the board, zero when they are not shown there
+func (m Model) detailsHeight() int {
+	if !m.showDetails || !m.detailsBelow {
+		return 0
+	}
+	return min(max(minDetailsHeight, m.boardHeight()/3), m.boardHeight()/2)
+}
+
+// mainWidth is the width of the board or the table next to the details
+func (m Model) mainWidth() int {
+	return max(0, m.windowWidth-m.detailsWidth())
+}
+
+// mainHeight is the height of the board or the table above the details
+func (m Model) mainHeight() int {
+	return max(0, m.boardHeight()-m.detailsHeight())
+}
+
+// resize gives the board, the table and the details the space they take
+func (m *Model) resize() {
+	m.resizeColumns()
+	m.table.SetSize(m.mainWidth(), m.mainHeight())
+	if m.detailsBelow {
+		m.details.SetSize(m.windowWidth, m.detailsHeight())
+	} else {
+		m.details.SetSize(m.detailsWidth(), m.mainHeight())
+	}
+}
+
+// currentTicket returns the ticket under the cursor of the table or the focused column
+func (m Model) currentTicket() (ticket.Ticket, bool) {
+	if m.showTable {
+		return m.table.Current()
+	}
+	if m.lanes[m.lane].collapsed {
+		return ticket.Ticket{}, false
+	}
+	return m.columns[m.focusedColumn()].Current()
+}
+
+// updateDetails shows the ticket under the cursor in the details,
+// changed reloads its history because the tickets were changed
+func (m *Model) updateDetails(changed bool) tea.Cmd {
+	if !m.loaded || !m.showDetails {
+		return nil
+	}
+	t, ok := m.currentTicket()
+	return m.details.SetTicket(t, ok, changed)
+}
+
+// viewDetails places the details next to or below the board
+func (m Model) viewDetails(board string) string {
+	if !m.showDetails {
+		return board
+	}
+	details := zone.Mark(detailsZone, m.details.View())
+	if m.detailsBelow {
+		return lipgloss.JoinVertical(lipgloss.Left, board, details)
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, board, details)
+}
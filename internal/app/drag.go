@@ -0,0 +1,142 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/Kavantix/kantui/internal/overlay"
+	"github.com/Kavantix/kantui/internal/theme"
+	"github.com/Kavantix/kantui/internal/ticket"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	zone "github.com/lrstanley/bubblezone"
+)
+
+// drag is a ticket that is pressed on and possibly being dragged to another position
+type drag struct {
+	ticket ticket.Ticket
+	startX int
+	startY int
+	// moving is set once the mouse moves, before that a press is a regular click
+	moving bool
+	x, y   int
+	// target is the index of the column the ticket is dropped in, -1 when outside the columns
+	target int
+	// index is the position in the visible tickets of the target column
+	index int
+}
+
+func (m Model) columnAt(msg tea.MouseMsg) int {
+	for i := range m.columns {
+		if zone.Get(columnZone(m.lane, i)).InBounds(msg) {
+			return i
+		}
+	}
+	return -1
+}
+
+// updateDrag tracks dragging tickets between and within columns,
+// handled is false when the message is left to the columns
+func (m Model) updateDrag(msg tea.MouseMsg) (_ Model, _ tea.Cmd, handled bool) {
+	switch msg.Action {
+	case tea.MouseActionPress:
+		m.drag = nil
+		if msg.Button != tea.MouseButtonLeft {
+			return m, nil, false
+		}
+		column := m.columnAt(msg)
+		if column < 0 {
+			return m, nil, false
+		}
+		if ticket, ok := m.columns[column].TicketAt(msg); ok {
+			m.drag = &drag{ticket: ticket, startX: msg.X, startY: msg.Y, target: -1}
+		}
+		return m, nil, false
+	case tea.MouseActionMotion:
+		if m.drag == nil || msg.Button != tea.MouseButtonLeft {
+			return m, nil, false
+		}
+		drag := *m.drag
+		if !drag.moving && msg.X == drag.startX && msg.Y == drag.startY {
+			return m, nil, true
+		}
+		drag.moving = true
+		drag.x, drag.y = msg.X, msg.Y
+		drag.target = m.columnAt(msg)
+		if drag.target >= 0 {
+			drag.index = m.columns[drag.target].DropIndex(msg)
+			m.focusColumn(drag.target)
+		}
+		m.drag = &drag
+		return m, nil, true
+	case tea.MouseActionRelease:
+		drag := m.drag
+		m.drag = nil
+		if drag == nil || !drag.moving {
+			return m, nil, false
+		}
+		if drag.target < 0 {
+			return m, nil, true
+		}
+		return m, m.drop(*drag), true
+	}
+	return m, nil, false
+}
+
+// focusColumn focuses the column in the focused lane, unfocusing the columns of all lanes
+func (m Model) focusColumn(index int) {
+	for _, l := range m.lanes {
+		for i := range l.columns {
+			l.columns[i].Unfocus()
+		}
+	}
+	m.columns[index].Focus()
+	// The focused column is wider than the others
+	m.resizeColumns()
+}
+
+// drop moves the dragged ticket to the column it was released in,
+// between the tickets around the drop position.
+// When the column is not shown in rank order the ticket keeps its rank,
+// like moving it with the keyboard, because the neighbours say nothing about the rank.
+func (m Model) drop(drag drag) tea.Cmd {
+	column := m.columns[drag.target]
+	tickets := column.VisibleTickets()
+	position := ticket.AtBottom()
+	switch {
+	case !column.InRankOrder():
+		position = ticket.InPlace()
+	case drag.index < len(tickets):
+		position = ticket.BeforeTicket(tickets[drag.index].ID)
+	case drag.index > 0:
+		position = ticket.AfterTicket(tickets[drag.index-1].ID)
+	}
+	move := m.store.MoveTicket(drag.ticket.ID, column.Status(), position)
+	return column.LimitMove(column.Status(), []ticket.Ticket{drag.ticket}, move)
+}
+
+// viewDrag draws the insertion marker and the dragged ticket next to the mouse
+func (m Model) viewDrag(view string) string {
+	if m.drag == nil || !m.drag.moving {
+		return view
+	}
+	theme := theme.Get()
+	if m.drag.target >= 0 && m.columns[m.drag.target].InRankOrder() {
+		x, y, width, ok := m.columns[m.drag.target].InsertionLine(m.drag.index)
+		if ok && width > 0 {
+			marker := lipgloss.NewStyle().
+				Foreground(theme.Selected).
+				Render("▶" + strings.Repeat("─", max(0, width-1)))
+			view = overlay.Place(x, y, marker, view, false)
+		}
+	}
+	title := ansi.Truncate(string(m.drag.ticket.Title), 36, "…")
+	ghost := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Selected).
+		Foreground(theme.Title).
+		Render(ticket.IdStyle().Render(m.drag.ticket.ID.String()) + " " + title)
+	x := min(m.drag.x+2, max(0, m.windowWidth-lipgloss.Width(ghost)))
+	y := min(m.drag.y, max(0, m.boardHeight()-lipgloss.Height(ghost)))
+	return overlay.Place(x, y, ghost, view, false)
+}
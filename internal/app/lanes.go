@@ -0,0 +1,315 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Kavantix/kantui/internal/column"
+	"github.com/Kavantix/kantui/internal/theme"
+	"github.com/Kavantix/kantui/internal/ticket"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+)
+
+// labelGrouping groups the tickets by their labels,
+// any other grouping is a label prefix like assignee for assignee:bob
+const labelGrouping = "label"
+
+// minLaneHeight is the height below which lanes are scrolled instead of shrunk
+const minLaneHeight = 10
+
+// laneHeaderHeight is the height of the header above each swimlane
+const laneHeaderHeight = 1
+
+// lane is a horizontal row of the board with its own columns,
+// without swimlanes the board is a single lane without a header
+type lane struct {
+	// name is the value of the grouping, empty for the tickets without one
+	name      string
+	collapsed bool
+	columns   []column.Model
+}
+
+// laneValues returns the values of the grouping of the ticket,
+// a ticket with multiple labels is shown in the lane of each of them
+func laneValues(grouping string, t ticket.Ticket) []string {
+	var values []string
+	for _, label := range t.Labels {
+		if grouping == labelGrouping {
+			values = append(values, string(label))
+			continue
+		}
+		name, value, ok := strings.Cut(string(label), ":")
+		if ok && value != "" && strings.EqualFold(name, grouping) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// laneNames returns the lanes of the grouping in the order they are shown,
+// the lane of the tickets without a value is always last
+func laneNames(grouping string, tickets []ticket.Ticket) []string {
+	var names []string
+	for _, t := range tickets {
+		for _, value := range laneValues(grouping, t) {
+			if !slices.Contains(names, value) {
+				names = append(names, value)
+			}
+		}
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	return append(names, "")
+}
+
+// groupings returns the groupings that can be used for the tickets,
+// the label prefixes are found in the labels of the board
+func groupings(tickets []ticket.Ticket) []string {
+	var prefixes []string
+	for _, t := range tickets {
+		for _, label := range t.Labels {
+			name, value, ok := strings.Cut(strings.ToLower(string(label)), ":")
+			if ok && name != "" && value != "" && !slices.Contains(prefixes, name) {
+				prefixes = append(prefixes, name)
+			}
+		}
+	}
+	slices.Sort(prefixes)
+	return append([]string{"", labelGrouping}, prefixes...)
+}
+
+// nextGrouping cycles from no swimlanes to grouping by label and then by each label prefix
+func (m *Model) nextGrouping() tea.Cmd {
+	options := groupings(m.tickets)
+	index := slices.Index(options, m.grouping)
+	m.grouping = options[(index+1)%len(options)]
+	cmd := m.applyQuery()
+	m.focusLane(0, m.focusedColumn())
+	return cmd
+}
+
+// filter returns the tickets that are shown in the lane
+func (l lane) filter(grouping string, tickets []ticket.Ticket) []ticket.Ticket {
+	if grouping == "" {
+		return tickets
+	}
+	var inLane []ticket.Ticket
+	for _, t := range tickets {
+		values := laneValues(grouping, t)
+		if l.name == "" && len(values) == 0 || l.name != "" && slices.Contains(values, l.name) {
+			inLane = append(inLane, t)
+		}
+	}
+	return inLane
+}
+
+// updateLanes creates the lanes for the tickets, keeping the columns of lanes that
+// are still there so their cursor, filter and selection are not lost
+func (m *Model) updateLanes(tickets []ticket.Ticket) {
+	names := []string{""}
+	if m.grouping != "" {
+		names = laneNames(m.grouping, tickets)
+	}
+	focusedLane := m.lanes[m.lane].name
+	focusedColumn := m.focusedColumn()
+	existing := map[string]lane{}
+	for _, l := range m.lanes {
+		existing[l.name] = l
+	}
+	lanes := make([]lane, 0, len(names))
+	for _, name := range names {
+		l, ok := existing[name]
+		if !ok {
+			l = lane{name: name, columns: m.newColumns()}
+		}
+		lanes = append(lanes, l)
+	}
+	index := slices.IndexFunc(lanes, func(l lane) bool { return l.name == focusedLane })
+	if index < 0 {
+		index = min(m.lane, len(lanes)-1)
+	}
+	m.lanes = lanes
+	for i, l := range m.lanes {
+		for _, c := range l.columns {
+			c.SetZonePrefix(fmt.Sprintf("lane-%d-", i))
+		}
+	}
+	m.focusLane(index, focusedColumn)
+	m.resizeColumns()
+}
+
+// newColumns creates the columns of a lane with the same layout as the focused lane
+func (m Model) newColumns() []column.Model {
+	var columns []column.Model
+	for _, template := range m.columns {
+		c := column.New(template.Status(), m.store, m.flags.ConfirmDelete())
+		c.SetHidden(template.Hidden())
+		c.SetCollapsed(template.Collapsed())
+		// The tickets are set right after, so the command of the sort is not needed
+		c.SetSort(template.Sort())
+		columns = append(columns, c)
+	}
+	return columns
+}
+
+func (m Model) focusedColumn() int {
+	for i, column := range m.columns {
+		if column.Focused() {
+			return i
+		}
+	}
+	return 0
+}
+
+// focusLane focuses the column at the index in the lane,
+// the columns of the board are those of the focused lane
+func (m *Model) focusLane(index, columnIndex int) {
+	m.lane = index
+	m.columns = m.lanes[index].columns
+	m.focusColumn(columnIndex)
+}
+
+// focusNeighbourLane focuses the same column in the lane above or below
+func (m *Model) focusNeighbourLane(offset int) {
+	next := m.lane + offset
+	if next < 0 || next >= len(m.lanes) {
+		return
+	}
+	m.focusLane(next, m.focusedColumn())
+}
+
+// toggleLane collapses the lane to only its header or expands it again
+func (m *Model) toggleLane(index int) {
+	if m.grouping == "" {
+		return
+	}
+	m.lanes[index].collapsed = !m.lanes[index].collapsed
+	m.resizeColumns()
+}
+
+// forColumn applies a layout change to the column at the index in all lanes
+func (m Model) forColumn(index int, apply func(c *column.Model)) {
+	for _, l := range m.lanes {
+		apply(&l.columns[index])
+	}
+}
+
+// laneHeights divides the height of the board over the expanded lanes,
+// the lanes are scrolled when they do not fit at their minimum height
+func (m Model) laneHeights() []int {
+	heights := make([]int, len(m.lanes))
+	if len(m.lanes) == 0 {
+		return heights
+	}
+	if m.grouping == "" {
+		heights[0] = m.lanesHeight()
+		return heights
+	}
+	expanded := 0
+	for _, l := range m.lanes {
+		if !l.collapsed {
+			expanded++
+		}
+	}
+	if expanded == 0 {
+		return heights
+	}
+	available := m.lanesHeight() - len(m.lanes)*laneHeaderHeight
+	height := available / expanded
+	remainder := available - height*expanded
+	if height < minLaneHeight {
+		// Only some of the lanes are shown, which fill the board together
+		shown := max(1, m.lanesHeight()/(minLaneHeight+laneHeaderHeight))
+		height = m.lanesHeight()/shown - laneHeaderHeight
+		remainder = 0
+	}
+	for i, l := range m.lanes {
+		if l.collapsed {
+			continue
+		}
+		heights[i] = height
+		if remainder > 0 {
+			heights[i]++
+			remainder--
+		}
+	}
+	return heights
+}
+
+func columnZone(lane, column int) string {
+	return fmt.Sprintf("lane-%d-column-%d", lane, column)
+}
+
+func laneZone(lane int) string {
+	return fmt.Sprintf("lane-%d", lane)
+}
+
+// viewLane renders the columns of the lane next to each other,
+// or only the focused one in the zoomed layout
+func (m Model) viewLane(index int) string {
+	zoomed := m.zoomed()
+	focused := m.focusedColumn()
+	columns := []string{}
+	for i, column := range m.lanes[index].columns {
+		if column.Hidden() || zoomed && i != focused {
+			continue
+		}
+		columns = append(columns, zone.Mark(columnZone(index, i), column.View()))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, columns...)
+}
+
+// viewLaneHeader renders the name of the lane and the amount of tickets in it
+func (m Model) viewLaneHeader(index int) string {
+	theme := theme.Get()
+	l := m.lanes[index]
+	arrow := "▾"
+	if l.collapsed {
+		arrow = "▸"
+	}
+	name := l.name
+	if name == "" {
+		name = "No " + m.grouping
+	}
+	visible := 0
+	for _, column := range l.columns {
+		count, _ := column.Counts()
+		visible += count
+	}
+	style := lipgloss.NewStyle().Foreground(theme.InactiveTitle)
+	if index == m.lane {
+		style = lipgloss.NewStyle().Foreground(theme.Title).Bold(true)
+	}
+	header := style.Render(arrow+" "+name) + " " +
+		lipgloss.NewStyle().Foreground(theme.Muted).Render(fmt.Sprint(visible))
+	return zone.Mark(laneZone(index), lipgloss.NewStyle().Width(m.mainWidth()).MaxHeight(laneHeaderHeight).Render(header))
+}
+
+// viewLanes renders the lanes below each other, starting at the lane that
+// keeps the focused lane on the screen
+func (m Model) viewLanes() string {
+	if m.grouping == "" {
+		return m.viewLane(0)
+	}
+	blocks := make([]string, len(m.lanes))
+	for i, l := range m.lanes {
+		blocks[i] = m.viewLaneHeader(i)
+		if !l.collapsed {
+			blocks[i] = lipgloss.JoinVertical(lipgloss.Left, blocks[i], m.viewLane(i))
+		}
+	}
+	start := m.lane
+	height := lipgloss.Height(blocks[start])
+	for start > 0 && height+lipgloss.Height(blocks[start-1]) <= m.lanesHeight() {
+		start--
+		height += lipgloss.Height(blocks[start])
+	}
+	return lipgloss.NewStyle().
+		Height(m.lanesHeight()).
+		MaxHeight(m.lanesHeight()).
+		Render(lipgloss.JoinVertical(lipgloss.Left, blocks[start:]...))
+}
@@ -0,0 +1,226 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/Kavantix/kantui/internal/column"
+	"github.com/Kavantix/kantui/internal/query"
+	"github.com/Kavantix/kantui/internal/theme"
+	"github.com/Kavantix/kantui/internal/ticket"
+	"github.com/Kavantix/kantui/internal/view"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+)
+
+// minColumnWidth is the width below which the board switches to the zoomed layout
+const minColumnWidth = 32
+
+// tabsHeight is the height of the tabs above the zoomed column
+const tabsHeight = 1
+
+// zoomed reports whether the columns do not fit next to each other,
+// in which case only the focused column is shown with tabs for the others
+func (m Model) zoomed() bool {
+	shown, width := 0, 0
+	for _, c := range m.columns {
+		switch {
+		case c.Hidden():
+			continue
+		case c.Collapsed():
+			width += column.CollapsedWidth
+		default:
+			width += minColumnWidth
+		}
+		shown++
+	}
+	return shown > 1 && width > m.mainWidth()
+}
+
+// lanesHeight is the height of the board below the tabs of the zoomed layout
+func (m Model) lanesHeight() int {
+	if m.zoomed() {
+		return max(0, m.mainHeight()-tabsHeight)
+	}
+	return m.mainHeight()
+}
+
+// columnWidths divides the width of the window over the columns that are shown,
+// collapsed columns only take the width of a strip and the focused column gets half a column extra
+func (m Model) columnWidths() []int {
+	widths := make([]int, len(m.columns))
+	if m.zoomed() {
+		for i := range widths {
+			widths[i] = m.mainWidth()
+		}
+		return widths
+	}
+	expanded, collapsed := 0, 0
+	for _, c := range m.columns {
+		switch {
+		case c.Hidden():
+		case c.Collapsed():
+			collapsed++
+		default:
+			expanded++
+		}
+	}
+	focused := m.focusedColumn()
+	widenFocused := expanded > 1 && !m.columns[focused].Hidden() && !m.columns[focused].Collapsed()
+	available := m.mainWidth() - collapsed*column.CollapsedWidth
+	units := 2 * expanded
+	if widenFocused {
+		units++
+	}
+	unit := available / max(1, units)
+	for i, c := range m.columns {
+		switch {
+		case c.Hidden():
+		case c.Collapsed():
+			widths[i] = column.CollapsedWidth
+		case widenFocused && i == focused:
+			// The focused column also gets the width that can not be divided
+			widths[i] = 3*unit + available - unit*units
+		default:
+			widths[i] = 2 * unit
+		}
+	}
+	return widths
+}
+
+// resizeColumns gives the columns of all lanes their width and the height of their lane
+func (m Model) resizeColumns() {
+	zoomed := m.zoomed()
+	widths := m.columnWidths()
+	heights := m.laneHeights()
+	for i, l := range m.lanes {
+		for j := range l.columns {
+			l.columns[j].SetZoomed(zoomed)
+			l.columns[j].SetSize(widths[j], heights[i])
+		}
+	}
+}
+
+// focusNeighbour focuses the next column that is shown in the direction of the offset,
+// wrapping around at the ends of the board
+func (m Model) focusNeighbour(offset int) {
+	for i, column := range m.columns {
+		if !column.Focused() {
+			continue
+		}
+		for step := 1; step < len(m.columns); step++ {
+			next := (i + step*offset + step*len(m.columns)) % len(m.columns)
+			if !m.columns[next].Hidden() {
+				m.focusColumn(next)
+				return
+			}
+		}
+		return
+	}
+}
+
+// hideFocusedColumn hides the focused column and focuses its neighbour,
+// the last column that is shown can not be hidden
+func (m Model) hideFocusedColumn() {
+	shown := 0
+	for _, column := range m.columns {
+		if !column.Hidden() {
+			shown++
+		}
+	}
+	if shown <= 1 {
+		return
+	}
+	focused := m.focusedColumn()
+	m.focusNeighbour(1)
+	m.forColumn(focused, func(c *column.Model) { c.SetHidden(true) })
+	m.resizeColumns()
+}
+
+// currentLayout returns the query and layout of the board as a view
+func (m Model) currentLayout() view.View {
+	current := view.View{
+		Name:    m.currentView,
+		Query:   m.query.String(),
+		Columns: map[ticket.Status]view.Column{},
+	}
+	for _, c := range m.columns {
+		layout := view.Column{Hidden: c.Hidden(), Collapsed: c.Collapsed()}
+		if c.Sort() != column.SortRank {
+			layout.Sort = c.Sort().String()
+		}
+		current.Columns[c.Status()] = layout
+	}
+	return current
+}
+
+// applyView takes over the query and layout of the view,
+// the zero view shows all tickets in all columns
+func (m *Model) applyView(v view.View) tea.Cmd {
+	q, err := query.Parse(v.Query)
+	if err != nil {
+		// The query was valid when it was saved, so this only happens
+		// when the language changed
+		q = query.Query{}
+	}
+	m.query = q
+	m.currentView = v.Name
+	var cmds []tea.Cmd
+	for i := range m.columns {
+		layout := v.Columns[m.columns[i].Status()]
+		sort, err := column.ParseSort(layout.Sort)
+		if err != nil {
+			sort = column.SortRank
+		}
+		m.forColumn(i, func(c *column.Model) {
+			c.SetHidden(layout.Hidden)
+			c.SetCollapsed(layout.Collapsed)
+			cmds = append(cmds, c.SetSort(sort))
+		})
+	}
+	// A view that hides all columns still shows the first one
+	if len(m.columns) > 0 && m.columns[0].Hidden() {
+		hidden := true
+		for _, column := range m.columns {
+			hidden = hidden && column.Hidden()
+		}
+		if hidden {
+			m.forColumn(0, func(c *column.Model) { c.SetHidden(false) })
+		}
+	}
+	for _, column := range m.columns {
+		if column.Focused() && column.Hidden() {
+			m.focusNeighbour(1)
+			break
+		}
+	}
+	m.resizeColumns()
+	return tea.Batch(append(cmds, m.applyQuery())...)
+}
+
+func tabZone(column int) string {
+	return fmt.Sprintf("tab-%d", column)
+}
+
+// viewTabs renders a tab per column that is shown for the zoomed layout,
+// with the amount of tickets in the focused lane
+func (m Model) viewTabs() string {
+	theme := theme.Get()
+	focused := m.focusedColumn()
+	tabs := []string{}
+	for i, c := range m.columns {
+		if c.Hidden() {
+			continue
+		}
+		visible, _ := c.Counts()
+		style := lipgloss.NewStyle().Padding(0, 1).Foreground(theme.InactiveTitle)
+		if i == focused {
+			style = style.Foreground(theme.AccentText).Background(theme.Accent).Bold(true)
+		}
+		tabs = append(tabs, zone.Mark(tabZone(i), style.Render(fmt.Sprintf("%s (%d)", c.Title(), visible))))
+	}
+	return lipgloss.NewStyle().
+		Width(m.mainWidth()).
+		MaxWidth(m.mainWidth()).
+		Render(lipgloss.JoinHorizontal(lipgloss.Top, tabs...))
+}
@@ -1,16 +1,30 @@
 package app
 
 import (
-	"fmt"
+	"errors"
 	"log/slog"
-	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/Kavantix/kantui/internal/column"
 	"github.com/Kavantix/kantui/internal/database"
+	"github.com/Kavantix/kantui/internal/details"
 	"github.com/Kavantix/kantui/internal/flags"
+	"github.com/Kavantix/kantui/internal/help"
+	"github.com/Kavantix/kantui/internal/keys"
 	"github.com/Kavantix/kantui/internal/messages"
 	"github.com/Kavantix/kantui/internal/overlay"
+	"github.com/Kavantix/kantui/internal/query"
+	"github.com/Kavantix/kantui/internal/search"
+	"github.com/Kavantix/kantui/internal/statusbar"
+	"github.com/Kavantix/kantui/internal/table"
+	"github.com/Kavantix/kantui/internal/theme"
 	"github.com/Kavantix/kantui/internal/ticket"
+	"github.com/Kavantix/kantui/internal/toast"
+	"github.com/Kavantix/kantui/internal/view"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -23,8 +37,43 @@ type Model struct {
 	windowWidth  int
 	windowHeight int
 	quitting     bool
-	columns      []column.Model
-	overlay      overlay.Model
+	// columns are the columns of the focused lane
+	columns []column.Model
+	overlay overlay.Model
+	store   ticket.Store
+	toast   toast.Model
+
+	database string
+	pending  int
+
+	drag *drag
+
+	// tickets are all tickets of the board, the columns only get the ones matching the query
+	tickets []ticket.Ticket
+	query   query.Query
+
+	// lanes split the board by the grouping, there is a single lane when the grouping is empty
+	lanes    []lane
+	lane     int
+	grouping string
+
+	// table shows all tickets in a table instead of the board while showTable is set
+	table     table.Model
+	showTable bool
+
+	// details show the ticket under the cursor next to the board, or below it when detailsBelow is set
+	details      details.Model
+	showDetails  bool
+	detailsBelow bool
+
+	viewStore view.Store
+	views     []view.View
+	// currentView is the name of the last view that was switched to or saved
+	currentView string
+
+	// pendingKeys are the keys typed so far of a key sequence
+	pendingKeys []string
+	sequenceId  int
 
 	criticalFailure messages.CriticalFailureMsg
 
@@ -42,15 +91,14 @@ func New(flags *flags.Context) Model {
 
 type LoadedMsg struct {
 	TicketStore ticket.Store
+	ViewStore   view.Store
+	Database    string
 }
 
 // Init implements tea.Model.
 func (m Model) Init() tea.Cmd {
 	return func() tea.Msg {
-		dbFile := "kantui.sqlite3"
-		if m.flags.DbFolder() != "" {
-			dbFile = path.Join(m.flags.DbFolder(), dbFile)
-		}
+		dbFile := m.flags.DbFile()
 		err := database.Migrate(dbFile, m.flags.RemigrateCount())
 		if err != nil {
 			return messages.CriticalFailureMsg{
@@ -65,14 +113,34 @@ func (m Model) Init() tea.Cmd {
 				FriendlyText: "Failed to open database",
 			}
 		}
+		absDbFile, err := filepath.Abs(dbFile)
+		if err != nil {
+			absDbFile = dbFile
+		}
+		tickets := ticket.NewStore(db)
 		return LoadedMsg{
-			TicketStore: ticket.NewStore(db),
+			TicketStore: tickets,
+			ViewStore:   view.NewStore(db, tickets),
+			Database:    absDbFile,
 		}
 	}
 }
 
 // Update implements tea.Model.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m, cmd := m.update(msg)
+	// The history of the ticket is reloaded whenever the tickets changed
+	var changed bool
+	switch msg := msg.(type) {
+	case ticket.TicketsUpdatedMsg:
+		changed = true
+	case ticket.WatchMsg:
+		changed = msg.Changed
+	}
+	return m, tea.Batch(cmd, m.updateDetails(changed))
+}
+
+func (m Model) update(msg tea.Msg) (Model, tea.Cmd) {
 	if m.criticalFailure.Err != nil {
 		return m, tea.Quit
 	}
@@ -81,53 +149,161 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case LoadedMsg:
 		m.columns = []column.Model{}
 		for _, status := range ticket.Statusses {
-			m.columns = append(m.columns, column.New(status, msg.TicketStore))
+			m.columns = append(m.columns, column.New(status, msg.TicketStore, m.flags.ConfirmDelete()))
 		}
 		m.columns[0].Focus()
+		m.lanes = []lane{{columns: m.columns}}
+		m.table = table.New(msg.TicketStore, m.flags.ConfirmDelete())
+		m.details = details.New(msg.TicketStore)
 		if m.windowWidth > 0 {
-			width := m.windowWidth / len(m.columns)
-			for _, column := range m.columns {
-				column.SetSize(width, m.windowHeight)
-			}
+			m.resize()
 		}
 		m.loaded = true
-		return m, msg.TicketStore.Load
+		m.store = msg.TicketStore
+		m.viewStore = msg.ViewStore
+		m.database = msg.Database
+		cmds := []tea.Cmd{
+			tea.SetWindowTitle("kantui: " + m.flags.Board()),
+			msg.TicketStore.Load,
+			msg.ViewStore.Load,
+			msg.TicketStore.WaitForActivity(),
+		}
+		if m.flags.Watch() {
+			cmds = append(cmds, msg.TicketStore.Watch())
+		}
+		if warning := m.flags.LegacyDbWarning(); warning != "" {
+			cmds = append(cmds, func() tea.Msg {
+				return messages.ErrorMsg{
+					Err:          errors.New(warning),
+					FriendlyText: "Using the board in the working directory",
+				}
+			})
+		}
+		return m, tea.Batch(cmds...)
 	case messages.CriticalFailureMsg:
 		m.criticalFailure = msg
 		return m, tea.ExitAltScreen
+	case messages.ErrorMsg:
+		m.toast = m.toast.Show(msg)
+		return m, nil
+	case ticket.ActivityMsg:
+		m.pending = msg.Pending
+		return m, m.store.WaitForActivity()
 	case tea.WindowSizeMsg:
 		m.windowWidth = msg.Width
 		m.windowHeight = msg.Height
-		if len(m.columns) > 0 {
-			width := msg.Width / len(m.columns)
-			for _, column := range m.columns {
-				column.SetSize(width, msg.Height)
-			}
-		}
+		m.resize()
+		// Modals are placed on top of the board, leaving the status bar visible
+		msg.Height = m.boardHeight()
 		m.overlay, cmd = m.overlay.Update(msg)
 		return m, cmd
+	case ticket.HistoryMsg:
+		m.details, cmd = m.details.Update(msg)
+		return m, cmd
 	case tea.MouseMsg:
-		for i := 0; i < len(m.columns); i++ {
-			if zone.Get(fmt.Sprintf("column-%d", i)).InBounds(msg) {
-				m.columns[i], cmd = m.columns[i].Update(msg)
+		if m.showDetails && !m.overlay.Focused() && zone.Get(detailsZone).InBounds(msg) {
+			m.details, cmd = m.details.Update(msg)
+			return m, cmd
+		}
+		if m.showTable {
+			if m.overlay.Focused() {
+				break
+			}
+			m.table, cmd = m.table.Update(msg)
+			return m, cmd
+		}
+		var handled bool
+		m, cmd, handled = m.updateDrag(msg)
+		if handled {
+			return m, cmd
+		}
+		if m.zoomed() && msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft {
+			for i := range m.columns {
+				if zone.Get(tabZone(i)).InBounds(msg) {
+					m.focusColumn(i)
+					return m, nil
+				}
+			}
+		}
+		for l, lane := range m.lanes {
+			if m.grouping != "" && zone.Get(laneZone(l)).InBounds(msg) {
+				if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft {
+					m.focusLane(l, m.focusedColumn())
+					m.toggleLane(l)
+				}
+				return m, nil
+			}
+			if lane.collapsed {
+				continue
+			}
+			for i := range lane.columns {
+				if zone.Get(columnZone(l, i)).InBounds(msg) {
+					lane.columns[i], cmd = lane.columns[i].Update(msg)
 
-				if msg.Action != tea.MouseActionPress || msg.Button != 1 {
+					if msg.Action != tea.MouseActionPress || msg.Button != 1 {
+						return m, cmd
+					}
+					m.focusLane(l, i)
 					return m, cmd
 				}
-				for j := 0; j < len(m.columns); j++ {
-					m.columns[j].Unfocus()
-				}
-				m.columns[i].Focus()
-				return m, cmd
 			}
 		}
+	case ticket.WatchMsg:
+		watch := m.store.Watch()
+		if msg.Err != nil {
+			// The next poll retries, so there is no need to bother the user
+			slog.Warn("Failed to check for changes", slog.String("error", msg.Err.Error()))
+			return m, watch
+		}
+		if !msg.Changed {
+			return m, watch
+		}
+		slog.Info("Tickets were changed externally")
+		m, cmd = m.update(ticket.TicketsUpdatedMsg{Tickets: msg.Tickets})
+		return m, tea.Batch(cmd, watch)
 	case ticket.TicketsUpdatedMsg:
-		var cmds []tea.Cmd
-		for i := range m.columns {
-			m.columns[i], cmd = m.columns[i].Update(msg)
-			cmds = append(cmds, cmd)
+		m.tickets = msg.Tickets
+		cmd = m.applyQuery()
+		return m, cmd
+	case query.ApplyMsg:
+		m.query = msg.Query
+		cmd = m.applyQuery()
+		return m, cmd
+	case view.UpdatedMsg:
+		m.views = msg.Views
+		// The current view was deleted, so the board no longer shows a saved view
+		if !slices.ContainsFunc(m.views, func(v view.View) bool { return v.Name == m.currentView }) {
+			m.currentView = ""
 		}
-		return m, tea.Batch(cmds...)
+		return m, nil
+	case view.ApplyMsg:
+		return m, m.applyView(msg.View)
+	case search.SelectMsg:
+		if !m.query.Match(msg.Ticket) {
+			m.query = query.Query{}
+			cmd = m.applyQuery()
+		}
+		if m.showTable {
+			m.table.ShowTicket(msg.Ticket.ID)
+			return m, cmd
+		}
+		for l, lane := range m.lanes {
+			for i, column := range lane.columns {
+				if column.Status() == msg.Ticket.Status && column.ShowTicket(msg.Ticket.ID) {
+					m.focusLane(l, i)
+					if lane.collapsed {
+						m.toggleLane(l)
+					}
+					return m, cmd
+				}
+			}
+		}
+		return m, cmd
+	case sequenceTimeoutMsg:
+		if msg.id == m.sequenceId {
+			m.pendingKeys = nil
+		}
+		return m, nil
 	case messages.QuitMsg:
 		slog.Info("Quitting")
 		m.quitting = true
@@ -138,49 +314,132 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if m.overlay.Focused() || cmd != nil {
 		return m, cmd
 	}
+	if !m.loaded {
+		// There are no columns yet to handle the message
+		return m, nil
+	}
+
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && !m.isCapturingInput() {
+		var waiting bool
+		msg, waiting, cmd = m.resolveSequence(keyMsg)
+		if waiting {
+			return m, cmd
+		}
+	}
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "q":
+		keyMap := keys.Get().Board
+		if key.Matches(msg, keyMap.ForceQuit) {
 			return m, messages.Quit
-		case "left", "h":
-			for i, column := range m.columns {
-				if column.Focused() {
-					if column.IsCapturingInput() {
-						break
-					}
-					prevIndex := i - 1
-					if prevIndex < 0 {
-						prevIndex = len(m.columns) - 1
-					}
-					m.columns[i].Unfocus()
-					m.columns[prevIndex].Focus()
-					return m, nil
-				}
+		}
+		if m.isCapturingInput() {
+			break
+		}
+		switch {
+		case key.Matches(msg, keyMap.Quit):
+			return m, messages.Quit
+		case key.Matches(msg, keyMap.Help):
+			return m, help.Show
+		case key.Matches(msg, keyMap.Search):
+			return m, search.Show(m.store)
+		case key.Matches(msg, keyMap.Query):
+			return m, query.Show(m.query)
+		case key.Matches(msg, keyMap.RetryError) && m.toast.Visible():
+			m.toast, cmd = m.toast.Retry()
+			return m, cmd
+		case key.Matches(msg, keyMap.DismissError) && m.toast.Visible():
+			m.toast = m.toast.Dismiss()
+			return m, nil
+		case key.Matches(msg, keyMap.Table):
+			m.showTable = !m.showTable
+			return m, nil
+		case key.Matches(msg, keyMap.Details):
+			m.showDetails = !m.showDetails
+			m.resize()
+			return m, m.updateDetails(true)
+		case key.Matches(msg, keyMap.MoveDetails):
+			m.showDetails = true
+			m.detailsBelow = !m.detailsBelow
+			m.resize()
+			return m, m.updateDetails(true)
+		case key.Matches(msg, keyMap.DetailsUp) && m.showDetails:
+			m.details.ScrollUp()
+			return m, nil
+		case key.Matches(msg, keyMap.DetailsDown) && m.showDetails:
+			m.details.ScrollDown()
+			return m, nil
+		case key.Matches(msg, keyMap.Views):
+			return m, view.ShowPicker(m.views, m.currentView, m.viewStore)
+		case key.Matches(msg, keyMap.SaveView):
+			return m, view.ShowSave(m.currentLayout(), m.viewStore)
+		case key.Matches(msg, keyMap.SwitchView):
+			// The position of the key in the binding is the number of the view
+			index := slices.Index(keyMap.SwitchView.Keys(), msg.String())
+			if index < 0 || index >= len(m.views) {
+				return m, nil
 			}
-		case "right", "l":
-			for i, column := range m.columns {
-				if column.Focused() {
-					if column.IsCapturingInput() {
-						break
-					}
-					nextIndex := i + 1
-					if nextIndex > len(m.columns)-1 {
-						nextIndex = 0
-					}
-					m.columns[i].Unfocus()
-					m.columns[nextIndex].Focus()
-					return m, nil
-				}
+			return m, m.applyView(m.views[index])
+		case key.Matches(msg, keyMap.ResetView):
+			return m, m.applyView(view.View{})
+		case m.showTable:
+			// The layout of the board does not apply to the table
+			m.table, cmd = m.table.Update(msg)
+			return m, cmd
+		case key.Matches(msg, keyMap.FocusLeft):
+			m.focusNeighbour(-1)
+			return m, nil
+		case key.Matches(msg, keyMap.FocusRight):
+			m.focusNeighbour(1)
+			return m, nil
+		case key.Matches(msg, keyMap.Collapse):
+			collapsed := !m.columns[m.focusedColumn()].Collapsed()
+			m.forColumn(m.focusedColumn(), func(c *column.Model) { c.SetCollapsed(collapsed) })
+			m.resizeColumns()
+			return m, nil
+		case key.Matches(msg, keyMap.Hide):
+			m.hideFocusedColumn()
+			return m, nil
+		case key.Matches(msg, keyMap.ShowHidden):
+			for i := range m.columns {
+				m.forColumn(i, func(c *column.Model) { c.SetHidden(false) })
 			}
+			m.resizeColumns()
+			return m, nil
+		case key.Matches(msg, keyMap.Swimlanes):
+			cmd = m.nextGrouping()
+			return m, cmd
+		case key.Matches(msg, keyMap.CollapseLane):
+			m.toggleLane(m.lane)
+			return m, nil
+		case key.Matches(msg, keyMap.PreviousLane):
+			m.focusNeighbourLane(-1)
+			return m, nil
+		case key.Matches(msg, keyMap.NextLane):
+			m.focusNeighbourLane(1)
+			return m, nil
 		}
 	}
 
-	for i, column := range m.columns {
-		if column.Focused() {
-			m.columns[i], cmd = column.Update(msg)
-		}
+	if m.showTable {
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	}
+	if m.lanes[m.lane].collapsed {
+		return m, nil
+	}
+	i := m.focusedColumn()
+	sort := m.columns[i].Sort()
+	m.columns[i], cmd = m.columns[i].Update(msg)
+	if newSort := m.columns[i].Sort(); newSort != sort {
+		// The sort order is part of the layout, which is the same for all lanes
+		cmds := []tea.Cmd{cmd}
+		m.forColumn(i, func(c *column.Model) {
+			if c.Sort() != newSort {
+				cmds = append(cmds, c.SetSort(newSort))
+			}
+		})
+		cmd = tea.Batch(cmds...)
 	}
 	return m, cmd
 
@@ -189,6 +448,83 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// return m, nil
 }
 
+// applyQuery gives the columns of each lane the tickets that match the query
+func (m *Model) applyQuery() tea.Cmd {
+	tickets := m.query.Filter(m.tickets)
+	m.updateLanes(tickets)
+	// Wip limits apply to the whole board, regardless of the query
+	counts := map[ticket.Status]int{}
+	for _, t := range m.tickets {
+		counts[t.Status]++
+	}
+	m.table.SetTickets(tickets)
+	var cmds []tea.Cmd
+	for _, lane := range m.lanes {
+		msg := ticket.TicketsUpdatedMsg{Tickets: lane.filter(m.grouping, tickets)}
+		for i := range lane.columns {
+			lane.columns[i].SetBoardCounts(counts)
+			var cmd tea.Cmd
+			lane.columns[i], cmd = lane.columns[i].Update(msg)
+			cmds = append(cmds, cmd)
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// sequenceTimeout is how long to wait for the next key of a sequence
+const sequenceTimeout = time.Second
+
+type sequenceTimeoutMsg struct {
+	id int
+}
+
+// resolveSequence collects the keys of a key sequence,
+// a completed sequence is returned as a single key so it can be matched like any other binding
+func (m *Model) resolveSequence(msg tea.KeyMsg) (_ tea.KeyMsg, waiting bool, _ tea.Cmd) {
+	pendingKeys := append(slices.Clone(m.pendingKeys), msg.String())
+	scope := keys.BoardScope
+	if m.showTable {
+		scope = keys.TableScope
+	}
+	complete, isPrefix := keys.Get().SequenceState(scope, pendingKeys)
+	switch {
+	case complete != "":
+		m.pendingKeys = nil
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(complete)}, false, nil
+	case isPrefix:
+		m.pendingKeys = pendingKeys
+		m.sequenceId++
+		id := m.sequenceId
+		return msg, true, tea.Tick(sequenceTimeout, func(time.Time) tea.Msg {
+			return sequenceTimeoutMsg{id: id}
+		})
+	case len(m.pendingKeys) > 0:
+		// The key does not continue the sequence so it is handled on its own
+		m.pendingKeys = nil
+		return m.resolveSequence(msg)
+	}
+	return msg, false, nil
+}
+
+// statusBarHeight is the amount of lines below the board
+const statusBarHeight = 1
+
+func (m Model) boardHeight() int {
+	return max(0, m.windowHeight-statusBarHeight)
+}
+
+func (m Model) isCapturingInput() bool {
+	if m.showTable {
+		return m.table.IsCapturingInput()
+	}
+	for _, column := range m.columns {
+		if column.Focused() && column.IsCapturingInput() {
+			return true
+		}
+	}
+	return false
+}
+
 // View implements tea.Model.
 func (m Model) View() string {
 	if m.quitting {
@@ -197,7 +533,7 @@ func (m Model) View() string {
 
 	if m.criticalFailure.Err != nil {
 		style := lipgloss.NewStyle().
-			Background(lipgloss.Color("9")).
+			Background(theme.Get().Error).
 			Margin(1, 0)
 
 		title := "Failed"
@@ -217,15 +553,117 @@ func (m Model) View() string {
 		return m.spinner.View()
 	}
 
-	columns := []string{}
-	for i, column := range m.columns {
-		columns = append(columns, zone.Mark(fmt.Sprintf("column-%d", i), column.View()))
+	var board string
+	switch {
+	case m.showTable:
+		board = m.table.View()
+	case m.zoomed():
+		board = lipgloss.JoinVertical(lipgloss.Left, m.viewTabs(), m.viewLanes())
+	default:
+		board = m.viewLanes()
+	}
+	board = m.viewDetails(board)
+	if m.toast.Visible() {
+		toast := m.toast.View(m.windowWidth)
+		board = overlay.Place(
+			m.windowWidth-lipgloss.Width(toast)-1,
+			m.boardHeight()-lipgloss.Height(toast)-1,
+			toast, board, false,
+		)
 	}
 
-	board := lipgloss.JoinHorizontal(
-		lipgloss.Center,
-		columns...,
-	)
+	return m.viewDrag(zone.Scan(lipgloss.JoinVertical(
+		lipgloss.Left,
+		m.overlay.View(board),
+		statusbar.View(m.windowWidth, m.status()),
+	)))
+}
 
-	return zone.Scan(m.overlay.View(board))
+func (m Model) status() statusbar.Status {
+	status := statusbar.Status{
+		Board:     m.flags.Board(),
+		Database:  m.database,
+		Pending:   m.pending,
+		LastError: m.toast.LastError(),
+		Keys:      strings.Join(m.pendingKeys, " "),
+		Hints:     m.keyHints(),
+	}
+	// A ticket with multiple labels is in multiple lanes but only counted once
+	visible := map[ticket.TicketId]bool{}
+	for _, lane := range m.lanes {
+		for _, column := range lane.columns {
+			for _, t := range column.VisibleTickets() {
+				visible[t.ID] = true
+			}
+			status.Selected += column.SelectedCount()
+			if filter := column.Filter(); filter != "" {
+				status.Filter = column.Title() + ": " + filter
+			}
+		}
+	}
+	status.Visible = len(visible)
+	status.Total = len(m.tickets)
+	if m.showTable {
+		status.Visible, _ = m.table.Counts()
+		status.Filter = ""
+		if filter := m.table.Filter(); filter != "" {
+			status.Filter = "table: " + filter
+		}
+	}
+	status.View = m.currentView
+	status.Swimlanes = m.grouping
+	if !m.query.IsEmpty() {
+		status.Query = m.query.String()
+	}
+	return status
+}
+
+func (m Model) keyHints() []key.Binding {
+	if modal := m.overlay.Top(); modal != nil {
+		if hinter, ok := modal.(statusbar.Hinter); ok {
+			return hinter.KeyHints()
+		}
+		return nil
+	}
+	keyMap := keys.Get()
+	if m.isCapturingInput() {
+		return []key.Binding{keyMap.Column.AcceptFilter, keyMap.Column.CancelFilter}
+	}
+	var hints []key.Binding
+	if m.toast.Visible() {
+		hints = append(hints, keyMap.Board.RetryError, keyMap.Board.DismissError)
+	}
+	if m.showTable {
+		return append(hints,
+			keyMap.Column.Create,
+			keyMap.Column.Edit,
+			keyMap.Column.NextStatus,
+			keyMap.Column.Filter,
+			keyMap.Column.Sort,
+			keyMap.Table.ReverseSort,
+			keyMap.Board.Table,
+			keyMap.Board.Help,
+		)
+	}
+	for _, column := range m.columns {
+		if column.Focused() && column.SelectedCount() > 0 {
+			return append(hints,
+				keyMap.Column.ToggleSelect,
+				keyMap.Column.Move,
+				keyMap.Column.Label,
+				keyMap.Column.Archive,
+				keyMap.Column.Delete,
+				keyMap.Column.ClearFilter,
+			)
+		}
+	}
+	return append(hints,
+		keyMap.Column.Create,
+		keyMap.Column.Edit,
+		keyMap.Column.Delete,
+		keyMap.Column.NextStatus,
+		keyMap.Column.Filter,
+		keyMap.Board.Help,
+		keyMap.Board.Quit,
+	)
 }
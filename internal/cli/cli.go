@@ -0,0 +1,103 @@
+// Package cli implements the commands that can be run without opening the board
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Kavantix/kantui/internal/database"
+	"github.com/Kavantix/kantui/internal/flags"
+	"github.com/Kavantix/kantui/internal/ticket"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(flags *flags.Context, args []string) error
+}
+
+var commands = []command{
+	{
+		name:  "init",
+		usage: "init [dir]",
+		run:   runInit,
+	},
+	{
+		name:  "import",
+		usage: "import trello|github|todotxt|markdown <file>",
+		run:   runImport,
+	},
+	{
+		name:  "export",
+		usage: "export todotxt|markdown [file]",
+		run:   runExport,
+	},
+	{
+		name:  "list",
+		usage: `list [-archived] [query], like list label:bug -status:done "login page"`,
+		run:   runList,
+	},
+	{
+		name:  "unarchive",
+		usage: "unarchive <id>...",
+		run:   runUnarchive,
+	},
+	{
+		name:  "search",
+		usage: "search <query>",
+		run:   runSearch,
+	},
+	{
+		name:  "serve",
+		usage: "serve [-addr 127.0.0.1:7730 | -socket <path>] [-token <token>]",
+		run:   runServe,
+	},
+}
+
+// Run runs the command given by the arguments left after parsing the flags
+func Run(flags *flags.Context) error {
+	args := flags.Args()
+	if len(args) == 0 {
+		return errors.New("no command given")
+	}
+	for _, command := range commands {
+		if command.name == args[0] {
+			return command.run(flags, args[1:])
+		}
+	}
+	return fmt.Errorf("unknown command %q, available commands:\n%s", args[0], Usage())
+}
+
+// Usage lists the available commands
+func Usage() string {
+	usage := strings.Builder{}
+	for _, command := range commands {
+		usage.WriteString("  kantui [flags] ")
+		usage.WriteString(command.usage)
+		usage.WriteRune('\n')
+	}
+	return usage.String()
+}
+
+func openStore(flags *flags.Context) (ticket.Store, []ticket.Ticket, error) {
+	if warning := flags.LegacyDbWarning(); warning != "" {
+		// Stderr keeps the output of exports to stdout intact
+		fmt.Fprintln(os.Stderr, "Warning:", warning)
+	}
+	dbFile := flags.DbFile()
+	if err := database.Migrate(dbFile, flags.RemigrateCount()); err != nil {
+		return nil, nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+	db, err := database.Open(dbFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	store := ticket.NewStore(db)
+	msg, err := ticket.Await(store.Load)
+	if err != nil {
+		return nil, nil, err
+	}
+	return store, msg.Tickets, nil
+}
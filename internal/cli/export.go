@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Kavantix/kantui/internal/exporter"
+	"github.com/Kavantix/kantui/internal/flags"
+	"github.com/Kavantix/kantui/internal/ticket"
+)
+
+var exporters = map[string]func(io.Writer, []ticket.Ticket) error{
+	"todotxt":  exporter.TodoTxt,
+	"markdown": exporter.Markdown,
+}
+
+func runExport(flags *flags.Context, args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return errors.New("usage: kantui export todotxt|markdown [file]")
+	}
+	export, ok := exporters[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown export format %q, expected todotxt or markdown", args[0])
+	}
+
+	_, tickets, err := openStore(flags)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		return export(os.Stdout, tickets)
+	}
+	file, err := os.Create(args[1])
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	if err := export(file, tickets); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	return file.Close()
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Kavantix/kantui/internal/flags"
+	"github.com/Kavantix/kantui/internal/importer"
+	"github.com/Kavantix/kantui/internal/ticket"
+)
+
+var importers = map[string]func(io.Reader) ([]ticket.Ticket, error){
+	"trello":   importer.Trello,
+	"github":   importer.GitHub,
+	"todotxt":  importer.TodoTxt,
+	"markdown": importer.Markdown,
+}
+
+func runImport(flags *flags.Context, args []string) error {
+	if len(args) != 2 {
+		return errors.New("usage: kantui import trello|github|todotxt|markdown <file>")
+	}
+	parse, ok := importers[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown import format %q, expected trello, github, todotxt or markdown", args[0])
+	}
+
+	file, err := os.Open(args[1])
+	if err != nil {
+		return fmt.Errorf("failed to open import file: %w", err)
+	}
+	defer file.Close()
+	tickets, err := parse(file)
+	if err != nil {
+		return err
+	}
+
+	store, _, err := openStore(flags)
+	if err != nil {
+		return err
+	}
+	if _, err := ticket.Await(store.Import(tickets)); err != nil {
+		return err
+	}
+	fmt.Printf("Imported %d tickets into %s\n", len(tickets), flags.DbFile())
+	return nil
+}
@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/Kavantix/kantui/internal/database"
+	"github.com/Kavantix/kantui/internal/flags"
+	"github.com/Kavantix/kantui/internal/project"
+)
+
+func runInit(flags *flags.Context, args []string) error {
+	if len(args) > 1 {
+		return errors.New("usage: kantui init [dir]")
+	}
+	dir := "."
+	if len(args) == 1 {
+		dir = args[0]
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	if err := project.Init(dir); err != nil {
+		return err
+	}
+	dbFile := project.DbFile(dir)
+	if err := database.Migrate(dbFile, 0); err != nil {
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+	fmt.Printf("Created board %s in %s\n", filepath.Base(dir), dbFile)
+	return nil
+}
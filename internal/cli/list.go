@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Kavantix/kantui/internal/flags"
+	"github.com/Kavantix/kantui/internal/messages"
+	"github.com/Kavantix/kantui/internal/query"
+	"github.com/Kavantix/kantui/internal/ticket"
+)
+
+func runList(flags *flags.Context, args []string) error {
+	// The flag is checked by hand, because negated terms like -status:done look like flags as well
+	archived := len(args) > 0 && (args[0] == "-archived" || args[0] == "--archived")
+	if archived {
+		args = args[1:]
+	}
+	q, err := query.Parse(strings.Join(args, " "))
+	if err != nil {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+	store, tickets, err := openStore(flags)
+	if err != nil {
+		return err
+	}
+	if archived {
+		tickets, err = loadArchived(store)
+		if err != nil {
+			return err
+		}
+	}
+	matching := q.Filter(tickets)
+	// Tickets are listed column by column like on the board
+	for _, status := range ticket.Statusses {
+		for _, t := range matching {
+			if t.Status != status {
+				continue
+			}
+			line := strings.Builder{}
+			line.WriteString(ticket.IdStyle().Render(t.ID.String()))
+			line.WriteString("  ")
+			line.WriteString(status.ColumnTitle())
+			line.WriteString("  ")
+			line.WriteString(string(t.Title))
+			for _, label := range t.Labels {
+				line.WriteString(" ")
+				line.WriteString(ticket.LabelStyle().Render("#" + string(label)))
+			}
+			fmt.Println(line.String())
+		}
+	}
+	return nil
+}
+
+func loadArchived(store ticket.Store) ([]ticket.Ticket, error) {
+	switch msg := store.LoadArchived().(type) {
+	case ticket.ArchivedTicketsMsg:
+		return msg.Tickets, nil
+	case messages.ErrorMsg:
+		return nil, fmt.Errorf("%s: %w", msg.FriendlyText, msg.Err)
+	default:
+		return nil, fmt.Errorf("unexpected result %T", msg)
+	}
+}
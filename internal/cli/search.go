@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Kavantix/kantui/internal/flags"
+	"github.com/Kavantix/kantui/internal/messages"
+	"github.com/Kavantix/kantui/internal/ticket"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const searchLimit = 50
+
+func runSearch(flags *flags.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: kantui search <query>")
+	}
+	store, _, err := openStore(flags)
+	if err != nil {
+		return err
+	}
+	query := strings.Join(args, " ")
+	msg := store.Search(query, searchLimit)()
+	if failure, ok := msg.(messages.ErrorMsg); ok {
+		return fmt.Errorf("%s: %w", failure.FriendlyText, failure.Err)
+	}
+	results := msg.(ticket.SearchResultsMsg).Results
+	if len(results) == 0 {
+		return fmt.Errorf("no tickets match %q", query)
+	}
+
+	matchStyle := lipgloss.NewStyle().Bold(true).Underline(true)
+	for _, result := range results {
+		t := result.Ticket
+		fmt.Printf("%s  %s  %s\n", ticket.IdStyle().Render(t.ID.String()), t.Status.ColumnTitle(), t.Title)
+		snippet := strings.Builder{}
+		plain := strings.Builder{}
+		for _, part := range result.Snippet {
+			// Descriptions can span multiple lines while the snippet is shown on one
+			text := strings.ReplaceAll(part.Text, "\n", " ")
+			plain.WriteString(text)
+			if part.Match {
+				snippet.WriteString(matchStyle.Render(text))
+			} else {
+				snippet.WriteString(text)
+			}
+		}
+		// The snippet is left out when the match is in the key or title that are shown already
+		switch strings.TrimSpace(plain.String()) {
+		case "", t.ID.String(), string(t.Title):
+		default:
+			fmt.Printf("    %s\n", strings.TrimSpace(snippet.String()))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/Kavantix/kantui/internal/flags"
+	"github.com/Kavantix/kantui/internal/server"
+)
+
+func runServe(flags *flags.Context, args []string) error {
+	serveFlags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := serveFlags.String("addr", "127.0.0.1:7730", "the localhost address to listen on")
+	socket := serveFlags.String("socket", "", "path of a unix socket to listen on instead of addr")
+	token := serveFlags.String("token", os.Getenv("KANTUI_TOKEN"), "token clients have to send as bearer token, defaults to $KANTUI_TOKEN or a random token when listening on addr")
+	if err := serveFlags.Parse(args); err != nil {
+		return err
+	}
+
+	listener, err := listen(*addr, *socket)
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	// Any local process can connect to a tcp port, a socket is protected by its file permissions
+	generated := *token == "" && *socket == ""
+	if generated {
+		*token, err = randomToken()
+		if err != nil {
+			return err
+		}
+	}
+
+	store, _, err := openStore(flags)
+	if err != nil {
+		return err
+	}
+
+	httpServer := &http.Server{Handler: server.New(store, *token)}
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-interrupt
+		httpServer.Close()
+	}()
+
+	fmt.Printf("Serving %s on %s\n", flags.DbFile(), listener.Addr())
+	switch {
+	case generated:
+		fmt.Printf("Token: %s\n", *token)
+	case *token == "":
+		fmt.Println("Warning: no token set, any process that can open the socket can modify the board")
+	}
+	err = httpServer.Serve(listener)
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// randomToken returns a token that is hard to guess, used when no token is given
+func randomToken() (string, error) {
+	bytes := make([]byte, 24)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("failed to generate a token: %w", err)
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+func listen(addr, socket string) (net.Listener, error) {
+	if socket != "" {
+		listener, err := net.Listen("unix", socket)
+		if err != nil {
+			return nil, fmt.Errorf("failed to listen on socket: %w", err)
+		}
+		return listener, nil
+	}
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %q: %w", addr, err)
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return nil, fmt.Errorf("refusing to listen on %q, only localhost addresses are allowed", addr)
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	return listener, nil
+}
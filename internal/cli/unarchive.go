@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Kavantix/kantui/internal/flags"
+	"github.com/Kavantix/kantui/internal/ticket"
+)
+
+func runUnarchive(flags *flags.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: kantui unarchive <id>...")
+	}
+	ids := make([]ticket.TicketId, len(args))
+	for i, arg := range args {
+		id, err := ticket.ParseTicketId(arg)
+		if err != nil {
+			return err
+		}
+		ids[i] = id
+	}
+	store, _, err := openStore(flags)
+	if err != nil {
+		return err
+	}
+	updated, err := ticket.Await(store.UnarchiveTickets(ids))
+	if err != nil {
+		return err
+	}
+	for _, t := range updated.Tickets {
+		for _, id := range ids {
+			if t.ID == id {
+				fmt.Printf("Unarchived %s to %s\n", t.ID, t.Status.ColumnTitle())
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package column
+
+import (
+	"github.com/Kavantix/kantui/internal/ticket"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	zone "github.com/lrstanley/bubblezone"
+)
+
+func (m Model) Status() ticket.Status {
+	return m.status
+}
+
+// VisibleTickets returns the tickets that match the filter in the order they are shown
+func (m Model) VisibleTickets() []ticket.Ticket {
+	var tickets []ticket.Ticket
+	for _, listItem := range m.list.VisibleItems() {
+		tickets = append(tickets, listItem.(item).ticket)
+	}
+	return tickets
+}
+
+// InRankOrder reports whether the tickets are shown in the order they are ranked,
+// which is not the case when they are sorted differently or filtered with a fuzzy filter
+func (m Model) InRankOrder() bool {
+	return m.sort == SortRank && m.list.FilterState() == list.Unfiltered
+}
+
+// pageBounds returns the range of the visible tickets that are on the current page
+func (m Model) pageBounds() (start, end int) {
+	return m.list.Paginator.GetSliceBounds(len(m.list.VisibleItems()))
+}
+
+// TicketAt returns the ticket under the mouse
+func (m Model) TicketAt(msg tea.MouseMsg) (ticket.Ticket, bool) {
+	tickets := m.VisibleTickets()
+	start, end := m.pageBounds()
+	for _, ticket := range tickets[start:end] {
+		if zone.Get(m.ticketZone(ticket.ID)).InBounds(msg) {
+			return ticket, true
+		}
+	}
+	return ticket.Ticket{}, false
+}
+
+// DropIndex returns the index in the visible tickets at which a ticket
+// that is dropped at the mouse position is inserted
+func (m Model) DropIndex(msg tea.MouseMsg) int {
+	tickets := m.VisibleTickets()
+	start, end := m.pageBounds()
+	for i := start; i < end; i++ {
+		bounds := zone.Get(m.ticketZone(tickets[i].ID))
+		if bounds.IsZero() {
+			continue
+		}
+		if msg.Y < bounds.StartY {
+			return i
+		}
+		if msg.Y <= bounds.EndY {
+			// The top half of a ticket inserts before it, the bottom half after it
+			if msg.Y-bounds.StartY < (bounds.EndY-bounds.StartY+1)/2 {
+				return i
+			}
+			return i + 1
+		}
+	}
+	return end
+}
+
+// InsertionLine returns the line on the screen where a ticket is inserted
+// when it is dropped at the given index, false when that line is not shown
+func (m Model) InsertionLine(index int) (x, y, width int, ok bool) {
+	tickets := m.VisibleTickets()
+	start, end := m.pageBounds()
+	if index < start || index > end || start == end {
+		return 0, 0, 0, false
+	}
+	// Tickets are separated by an empty line
+	if index < end {
+		bounds := zone.Get(m.ticketZone(tickets[index].ID))
+		return bounds.StartX, bounds.StartY - 1, bounds.EndX - bounds.StartX + 1, !bounds.IsZero()
+	}
+	bounds := zone.Get(m.ticketZone(tickets[index-1].ID))
+	return bounds.StartX, bounds.EndY + 1, bounds.EndX - bounds.StartX + 1, !bounds.IsZero()
+}
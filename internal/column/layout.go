@@ -0,0 +1,77 @@
+package column
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Kavantix/kantui/internal/theme"
+	"github.com/Kavantix/kantui/internal/ticket"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// CollapsedWidth is the width of a collapsed column including its border
+const CollapsedWidth = 5
+
+func (m Model) Collapsed() bool {
+	return m.collapsed
+}
+
+// SetCollapsed shows the column as a narrow strip, the column does not
+// handle keys while it is collapsed
+func (m *Model) SetCollapsed(collapsed bool) {
+	m.collapsed = collapsed
+}
+
+// SetZoomed shows the column expanded as the only column of the board
+func (m *Model) SetZoomed(zoomed bool) {
+	m.zoomed = zoomed
+}
+
+func (m Model) showCollapsed() bool {
+	return m.collapsed && !m.zoomed
+}
+
+func (m Model) Hidden() bool {
+	return m.hidden
+}
+
+// SetHidden leaves the column out of the board, which is done by the app
+func (m *Model) SetHidden(hidden bool) {
+	m.hidden = hidden
+}
+
+// SetZonePrefix is prepended to the zones of the tickets,
+// columns that can show the same ticket need a different prefix
+func (m Model) SetZonePrefix(prefix string) {
+	m.delegate.zonePrefix = prefix
+}
+
+func (m Model) ticketZone(id ticket.TicketId) string {
+	return m.delegate.zonePrefix + id.String()
+}
+
+// viewCollapsed renders the count and the title from top to bottom
+func (m Model) viewCollapsed() string {
+	theme := theme.Get()
+	style := style()
+	if m.focused {
+		style = style.
+			Border(theme.FocusedBorderShape).
+			BorderForeground(theme.FocusedBorder)
+	}
+	visible, _ := m.Counts()
+	lines := []string{
+		"",
+		lipgloss.NewStyle().Foreground(theme.Highlight).Bold(true).Render(fmt.Sprint(visible)),
+		"",
+	}
+	titleStyle := m.list.Styles.Title.UnsetPadding()
+	for _, r := range strings.ReplaceAll(m.status.ColumnTitle(), " ", "") {
+		lines = append(lines, titleStyle.Render(string(r)))
+	}
+	return style.
+		Width(CollapsedWidth - 2).
+		Height(m.list.Height()).
+		Align(lipgloss.Center).
+		Render(strings.Join(lines, "\n"))
+}
@@ -7,7 +7,12 @@ import (
 	"time"
 
 	"github.com/Kavantix/kantui/internal/confirm"
+	"github.com/Kavantix/kantui/internal/keys"
+	"github.com/Kavantix/kantui/internal/label"
+	"github.com/Kavantix/kantui/internal/move"
+	"github.com/Kavantix/kantui/internal/theme"
 	"github.com/Kavantix/kantui/internal/ticket"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -15,13 +20,29 @@ import (
 )
 
 type Model struct {
-	store ticket.Store
+	store         ticket.Store
+	confirmDelete bool
 
 	delegate *listDelegate
 
-	status  ticket.Status
-	focused bool
-	list    *list.Model
+	status ticket.Status
+	// titleStyle is the style of the header before it is colored for the wip limit
+	titleStyle lipgloss.Style
+	// boardCounts are the amount of tickets per status on the whole board
+	boardCounts map[ticket.Status]int
+	focused     bool
+	list        *list.Model
+	// tickets are the tickets given to the column in rank order, including
+	// those of other columns, so they can be sorted again
+	tickets []ticket.Ticket
+	sort    Sort
+	// collapsed columns are shown as a narrow strip with only the title and count
+	collapsed bool
+	// zoomed columns fill the board, which shows them expanded even when they are collapsed
+	zoomed bool
+	hidden bool
+	// selected are the tickets bulk actions apply to, shared with the delegate
+	selected map[ticket.TicketId]bool
 
 	lastClick *struct {
 		ticketId ticket.TicketId
@@ -30,51 +51,96 @@ type Model struct {
 }
 
 type item struct {
-	ticket ticket.Ticket
+	ticket   ticket.Ticket
+	selected bool
 }
 
+// selectedMarker is appended so the matches of the filter still line up with the title
+const selectedMarker = " ●"
+
 func (i item) Title() string {
-	return string(i.ticket.Title) + " " + i.ticket.ID.String()
+	title := string(i.ticket.Title) + " " + i.ticket.ID.String() + i.labels()
+	if i.selected {
+		return title + selectedMarker
+	}
+	return title
 }
 func (i item) Description() string { return string(i.ticket.Description) }
-func (i item) FilterValue() string { return string(i.ticket.Title) + " " + i.ticket.ID.String() }
+func (i item) FilterValue() string {
+	return string(i.ticket.Title) + " " + i.ticket.ID.String() + i.labels()
+}
+
+func (i item) labels() string {
+	labels := strings.Builder{}
+	for _, label := range i.ticket.Labels {
+		labels.WriteString(" #")
+		labels.WriteString(string(label))
+	}
+	return labels.String()
+}
 
 var defaultStyles = list.NewDefaultItemStyles()
 
 type listDelegate struct {
 	list.DefaultDelegate
-	width int
+	width    int
+	selected map[ticket.TicketId]bool
+	// zonePrefix keeps the zones of a ticket unique when it is shown in multiple swimlanes
+	zonePrefix string
 }
 
 func (d listDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
 	buffer := strings.Builder{}
-	d.DefaultDelegate.Render(&buffer, m, index, listItem)
-	id := listItem.(item).ticket.ID.String()
+	ticketItem := listItem.(item)
+	ticketItem.selected = d.selected[ticketItem.ticket.ID]
+	if ticketItem.selected {
+		d.Styles.NormalTitle = d.Styles.NormalTitle.Bold(true)
+		d.Styles.SelectedTitle = d.Styles.SelectedTitle.Bold(true)
+	}
+	d.DefaultDelegate.Render(&buffer, m, index, ticketItem)
+	id := ticketItem.ticket.ID.String()
 	content := buffer.String()
+	if ticketItem.selected {
+		content = strings.Replace(content, selectedMarker, lipgloss.NewStyle().Foreground(theme.Get().Selected).Render(selectedMarker), 1)
+	}
 	content = strings.Replace(content, id, ticket.IdStyle().Render(id), 1)
-	fmt.Fprint(w, zone.Mark(id, lipgloss.NewStyle().Width(d.width).Render(content)))
+	for _, label := range ticketItem.ticket.Labels {
+		label := "#" + string(label)
+		content = strings.Replace(content, label, ticket.LabelStyle().Render(label), 1)
+	}
+	fmt.Fprint(w, zone.Mark(d.zonePrefix+id, lipgloss.NewStyle().Width(d.width).Render(content)))
 }
 
-func New(status ticket.Status, store ticket.Store) Model {
-	delegate := listDelegate{list.NewDefaultDelegate(), 0}
+func New(status ticket.Status, store ticket.Store, confirmDelete bool) Model {
+	selected := map[ticket.TicketId]bool{}
+	delegate := listDelegate{list.NewDefaultDelegate(), 0, selected, ""}
 	listModel := list.New(
 		[]list.Item{},
 		&delegate, 0, 0,
 	)
 	listModel.SetShowHelp(false)
+	listModel.KeyMap = keys.Get().Column.ListKeyMap()
 	listModel.Title = status.ColumnTitle()
+	theme := theme.Get()
+	listModel.Styles.Title = listModel.Styles.Title.
+		Foreground(theme.AccentText).
+		Background(theme.Accent)
 	switch status {
 	case ticket.InProgress:
-		listModel.Styles.Title = listModel.Styles.Title.Background(lipgloss.Color("4"))
+		listModel.Styles.Title = listModel.Styles.Title.Background(theme.InProgress)
 	case ticket.Done:
-		listModel.Styles.Title = listModel.Styles.Title.Background(lipgloss.Color("2"))
+		listModel.Styles.Title = listModel.Styles.Title.Background(theme.Done)
 	}
 	m := Model{
-		delegate: &delegate,
-		store:    store,
-		status:   status,
-		list:     &listModel,
+		titleStyle:    listModel.Styles.Title,
+		delegate:      &delegate,
+		store:         store,
+		confirmDelete: confirmDelete,
+		status:        status,
+		list:          &listModel,
+		selected:      selected,
 	}
+	m.updateTitle()
 	return m
 }
 
@@ -95,23 +161,66 @@ func (m Model) Focused() bool {
 	return m.focused
 }
 
+func (m Model) Title() string {
+	return m.list.Title
+}
+
 func (m Model) IsCapturingInput() bool {
 	return m.list.SettingFilter()
 }
 
-var (
-	style = lipgloss.NewStyle().
+// Counts returns the amount of tickets matching the filter and the total
+// amount of tickets in the column
+func (m Model) Counts() (visible, total int) {
+	return len(m.list.VisibleItems()), len(m.list.Items())
+}
+
+// Filter returns the filter that is being typed or applied,
+// empty when the column is not filtered
+func (m Model) Filter() string {
+	if m.list.FilterState() == list.Unfiltered {
+		return ""
+	}
+	return m.list.FilterValue()
+}
+
+// ShowTicket moves the cursor to the ticket,
+// the filter is cleared when it hides the ticket
+func (m Model) ShowTicket(id ticket.TicketId) bool {
+	index := func() int {
+		for i, listItem := range m.list.VisibleItems() {
+			if listItem.(item).ticket.ID == id {
+				return i
+			}
+		}
+		return -1
+	}
+	i := index()
+	if i < 0 && m.list.FilterState() != list.Unfiltered {
+		m.list.ResetFilter()
+		i = index()
+	}
+	if i < 0 {
+		return false
+	}
+	m.list.Select(i)
+	return true
+}
+
+func style() lipgloss.Style {
+	return lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("239"))
-)
+		BorderForeground(theme.Get().Border)
+}
 
 func (m Model) SetSize(width, height int) {
-	styleX, styleY := style.GetFrameSize()
+	styleX, styleY := style().GetFrameSize()
 	m.list.SetSize(width-styleX, height-styleY)
 	m.delegate.width = width - styleX
 }
 
-func (m Model) setTickets(tickets []ticket.Ticket) tea.Cmd {
+func (m *Model) setTickets(tickets []ticket.Ticket) tea.Cmd {
+	m.tickets = tickets
 	var selectedTicketId ticket.TicketId
 	visibleItems := m.list.VisibleItems()
 	selectedIndex := m.list.Index()
@@ -124,14 +233,30 @@ func (m Model) setTickets(tickets []ticket.Ticket) tea.Cmd {
 
 	var items []list.Item
 	var newSelectedIndex = selectedIndex
+	inColumn := map[ticket.TicketId]bool{}
+	var inStatus []ticket.Ticket
 	for _, ticket := range tickets {
 		if ticket.Status == m.status {
-			if ticket.ID == selectedTicketId {
-				newSelectedIndex = len(items)
-			}
-			items = append(items, item{ticket: ticket})
+			inStatus = append(inStatus, ticket)
+		}
+	}
+	for _, ticket := range m.sort.sorted(inStatus) {
+		inColumn[ticket.ID] = true
+		if ticket.ID == selectedTicketId {
+			newSelectedIndex = len(items)
+		}
+		items = append(items, item{ticket: ticket})
+	}
+	// Tickets that left the column are no longer part of the selection
+	for id := range m.selected {
+		if !inColumn[id] {
+			delete(m.selected, id)
 		}
 	}
+	if newSelectedIndex >= len(items) {
+		// The selected ticket was removed, possibly by another process
+		newSelectedIndex = max(0, len(items)-1)
+	}
 	cmd := m.list.SetItems(items)
 	if newSelectedIndex != selectedIndex {
 		m.list.Select(newSelectedIndex)
@@ -157,8 +282,13 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				visibleItems := newListModel.VisibleItems()
 				for i, listItem := range visibleItems {
 					item := listItem.(item)
-					if zone.Get(item.ticket.ID.String()).InBounds(msg) {
+					if zone.Get(m.ticketZone(item.ticket.ID)).InBounds(msg) {
 						newListModel.Select(i)
+						if msg.Shift {
+							m.toggleSelected(item.ticket.ID)
+							m.lastClick = nil
+							break
+						}
 						if m.lastClick != nil &&
 							m.lastClick.ticketId == item.ticket.ID &&
 							time.Since(m.lastClick.at) < 500*time.Millisecond {
@@ -180,61 +310,99 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			return m, cmd
 		}
 	case tea.KeyMsg:
+		if m.showCollapsed() {
+			return m, nil
+		}
 		if m.IsCapturingInput() {
 			break
 		}
-		switch msg.String() {
-		case "esc":
-			if m.list.IsFiltered() {
+		keyMap := keys.Get().Column
+		// Ranking only makes sense when the tickets are shown in rank order
+		ranking := key.Matches(msg, keyMap.RankUp, keyMap.RankDown, keyMap.RankTop, keyMap.RankBottom)
+		if ranking && m.sort != SortRank {
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, keyMap.Sort):
+			return m, m.SetSort((m.sort + 1) % Sort(numberOfSorts))
+		case key.Matches(msg, keyMap.ClearFilter):
+			if len(m.selected) > 0 {
+				m.clearSelection()
+			} else if m.list.IsFiltered() {
 				m.list.ResetFilter()
 			}
 			return m, nil
-		case "c":
-			return m, ticket.CreateTicket(m.store)
-		case "d":
+		case key.Matches(msg, keyMap.ToggleSelect):
 			item, ok := m.list.SelectedItem().(item)
 			if !ok {
 				return m, nil
 			}
-			msgBuilder := strings.Builder{}
-			msgBuilder.WriteString("Are you sure you want to delete ")
-			msgBuilder.WriteString(ticket.IdStyle().Render(item.ticket.ID.String()))
-			msgBuilder.WriteRune('?')
-			return m, confirm.Show(msgBuilder.String(), m.store.DeleteTicket(item.ticket.ID))
-		case "e", " ":
-			item, ok := m.list.SelectedItem().(item)
-			if !ok {
+			m.toggleSelected(item.ticket.ID)
+			m.list.CursorDown()
+			return m, nil
+		case key.Matches(msg, keyMap.SelectAll):
+			m.toggleAllVisible()
+			return m, nil
+		case key.Matches(msg, keyMap.Create):
+			return m, ticket.CreateTicket(m.store)
+		case key.Matches(msg, keyMap.Delete):
+			targets := m.targets()
+			if len(targets) == 0 {
 				return m, nil
 			}
-			return m, ticket.EditTicket(item.ticket, m.store)
-		case "b":
-			item, ok := m.list.SelectedItem().(item)
-			if !ok {
+			deleteTickets := m.store.DeleteTickets(ids(targets))
+			if !m.confirmDelete {
+				return m, deleteTickets
+			}
+			return m, confirm.Show("Are you sure you want to delete "+describe(targets)+"?", deleteTickets)
+		case key.Matches(msg, keyMap.Archive):
+			targets := m.targets()
+			if len(targets) == 0 {
+				return m, nil
+			}
+			archiveTickets := m.store.ArchiveTickets(ids(targets))
+			if len(targets) == 1 {
+				return m, archiveTickets
+			}
+			return m, confirm.Show("Are you sure you want to archive "+describe(targets)+"?", archiveTickets)
+		case key.Matches(msg, keyMap.Label):
+			targets := m.targets()
+			if len(targets) == 0 {
 				return m, nil
 			}
-			return m, m.store.MoveToPreviousStatus(item.ticket.ID)
-		case "n":
+			return m, label.Show(ids(targets), m.store)
+		case key.Matches(msg, keyMap.Edit):
 			item, ok := m.list.SelectedItem().(item)
 			if !ok {
 				return m, nil
 			}
-			return m, m.store.MoveToNextStatus(item.ticket.ID)
-		case "J", "shift+down":
+			return m, ticket.EditTicket(item.ticket, m.store)
+		case key.Matches(msg, keyMap.Move):
+			targets := m.targets()
+			if len(targets) == 0 {
+				return m, nil
+			}
+			return m, move.Show(targets, m.store, m.LimitMove)
+		case key.Matches(msg, keyMap.PreviousStatus):
+			return m, m.moveToNeighbour(-1, m.store.MoveToPreviousStatus)
+		case key.Matches(msg, keyMap.NextStatus):
+			return m, m.moveToNeighbour(1, m.store.MoveToNextStatus)
+		case key.Matches(msg, keyMap.RankDown):
 			visibleItems := m.list.VisibleItems()
 			index := m.list.Index()
 			newIndex := index + 1
 			return m.rankDown(index, newIndex, visibleItems)
-		case "B":
+		case key.Matches(msg, keyMap.RankBottom):
 			visibleItems := m.list.VisibleItems()
 			index := m.list.Index()
 			newIndex := len(visibleItems) - 1
 			return m.rankDown(index, newIndex, visibleItems)
-		case "K", "shift+up":
+		case key.Matches(msg, keyMap.RankUp):
 			visibleItems := m.list.VisibleItems()
 			index := m.list.Index()
 			newIndex := index - 1
 			return m.rankUp(index, newIndex, visibleItems)
-		case "T":
+		case key.Matches(msg, keyMap.RankTop):
 			visibleItems := m.list.VisibleItems()
 			index := m.list.Index()
 			newIndex := 0
@@ -246,6 +414,34 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	return m, cmd
 }
 
+// moveToNeighbour moves the ticket under the cursor with the given store method,
+// or all selected tickets to the neighbouring column, both keep the rank of the tickets
+func (m Model) moveToNeighbour(offset int, moveOne func(ticket.TicketId) tea.Cmd) tea.Cmd {
+	targets := m.targets()
+	if len(targets) == 0 {
+		return nil
+	}
+	status, ok := m.neighbourStatus(offset)
+	if len(m.selected) == 0 {
+		if !ok {
+			return moveOne(targets[0].ID)
+		}
+		return m.LimitMove(status, targets, moveOne(targets[0].ID))
+	}
+	if !ok {
+		return nil
+	}
+	return m.LimitMove(status, targets, m.store.MoveTickets(ids(targets), status, ticket.InPlace()))
+}
+
+// describe names the ticket, or the amount of tickets when there are multiple
+func describe(tickets []ticket.Ticket) string {
+	if len(tickets) == 1 {
+		return ticket.IdStyle().Render(tickets[0].ID.String())
+	}
+	return fmt.Sprintf("%d tickets", len(tickets))
+}
+
 func (m Model) rankUp(index int, newIndex int, visibleItems []list.Item) (Model, tea.Cmd) {
 	if index == newIndex || newIndex < 0 || index > len(visibleItems)-1 {
 		return m, nil
@@ -266,24 +462,35 @@ func (m Model) rankDown(index int, newIndex int, visibleItems []list.Item) (Mode
 
 // View implements tea.Model.
 func (m Model) View() string {
-	borderColor := style.GetBorderTopForeground()
+	if m.showCollapsed() {
+		return m.viewCollapsed()
+	}
+	theme := theme.Get()
+	style := style()
+	m.delegate.Styles.NormalDesc = defaultStyles.NormalDesc.Foreground(theme.Description)
+	m.delegate.Styles.DimmedDesc = defaultStyles.DimmedDesc.Foreground(theme.Description)
 	if m.focused {
-		borderColor = lipgloss.Color("13")
-		m.delegate.Styles.SelectedTitle = defaultStyles.SelectedTitle
-		m.delegate.Styles.SelectedDesc = defaultStyles.SelectedDesc
+		style = style.
+			Border(theme.FocusedBorderShape).
+			BorderForeground(theme.FocusedBorder)
+		m.delegate.Styles.NormalTitle = defaultStyles.NormalTitle.Foreground(theme.Title)
+		m.delegate.Styles.SelectedTitle = defaultStyles.SelectedTitle.
+			BorderForeground(theme.Selected).
+			Foreground(theme.Selected)
+		m.delegate.Styles.SelectedDesc = defaultStyles.SelectedDesc.
+			BorderForeground(theme.Selected).
+			Foreground(theme.SelectedDescription)
 	} else {
+		m.delegate.Styles.NormalTitle = defaultStyles.NormalTitle.Foreground(theme.InactiveTitle)
 		m.delegate.Styles.SelectedTitle = defaultStyles.SelectedTitle.
-			BorderForeground(lipgloss.Color("33")).
-			Foreground(lipgloss.Color("248"))
-
+			BorderForeground(theme.InactiveSelected).
+			Foreground(theme.InactiveTitle)
 		m.delegate.Styles.SelectedDesc = defaultStyles.SelectedDesc.
-			BorderForeground(lipgloss.Color("68")).
-			Foreground(defaultStyles.NormalDesc.GetForeground())
-		m.delegate.Styles.NormalTitle = defaultStyles.NormalTitle.Foreground(lipgloss.Color("248"))
+			BorderForeground(theme.InactiveSelectedDescription).
+			Foreground(theme.Description)
 	}
 	return style.
 		Width(m.list.Width()).
 		Height(m.list.Height()).
-		BorderForeground(borderColor).
 		Render(m.list.View())
 }
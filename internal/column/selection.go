@@ -0,0 +1,93 @@
+package column
+
+import (
+	"github.com/Kavantix/kantui/internal/ticket"
+)
+
+// SelectedCount returns the amount of tickets that are selected for bulk actions
+func (m Model) SelectedCount() int {
+	return len(m.selected)
+}
+
+func (m Model) isSelected(id ticket.TicketId) bool {
+	return m.selected[id]
+}
+
+func (m Model) toggleSelected(id ticket.TicketId) {
+	if m.selected[id] {
+		delete(m.selected, id)
+	} else {
+		m.selected[id] = true
+	}
+}
+
+// toggleAllVisible selects the tickets that match the filter,
+// or clears the selection when they all are selected already
+func (m Model) toggleAllVisible() {
+	tickets := m.VisibleTickets()
+	allSelected := true
+	for _, ticket := range tickets {
+		allSelected = allSelected && m.selected[ticket.ID]
+	}
+	for _, ticket := range tickets {
+		if allSelected {
+			delete(m.selected, ticket.ID)
+		} else {
+			m.selected[ticket.ID] = true
+		}
+	}
+}
+
+func (m Model) clearSelection() {
+	clear(m.selected)
+}
+
+// Current returns the ticket under the cursor
+func (m Model) Current() (ticket.Ticket, bool) {
+	item, ok := m.list.SelectedItem().(item)
+	if !ok {
+		return ticket.Ticket{}, false
+	}
+	return item.ticket, true
+}
+
+// targets returns the tickets an action applies to,
+// the selected tickets or otherwise the ticket under the cursor
+func (m Model) targets() []ticket.Ticket {
+	if len(m.selected) == 0 {
+		current, ok := m.Current()
+		if !ok {
+			return nil
+		}
+		return []ticket.Ticket{current}
+	}
+	var targets []ticket.Ticket
+	for _, listItem := range m.list.Items() {
+		if ticket := listItem.(item).ticket; m.selected[ticket.ID] {
+			targets = append(targets, ticket)
+		}
+	}
+	return targets
+}
+
+func ids(tickets []ticket.Ticket) []ticket.TicketId {
+	ids := make([]ticket.TicketId, len(tickets))
+	for i, ticket := range tickets {
+		ids[i] = ticket.ID
+	}
+	return ids
+}
+
+// neighbourStatus returns the status of the column next to this one
+func (m Model) neighbourStatus(offset int) (ticket.Status, bool) {
+	for i, status := range ticket.Statusses {
+		if status == m.status {
+			next := i + offset
+			if next < 0 || next >= len(ticket.Statusses) {
+				return status, false
+			}
+			return ticket.Statusses[next], true
+		}
+	}
+	return m.status, false
+}
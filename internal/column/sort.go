@@ -0,0 +1,83 @@
+package column
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Kavantix/kantui/internal/ticket"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Sort is the order in which a column shows its tickets
+type Sort int
+
+const (
+	// SortRank is the order the tickets are ranked in, which can be changed manually
+	SortRank Sort = iota
+	SortNewest
+	SortOldest
+	SortTitle
+
+	numberOfSorts = int(iota)
+)
+
+func (s Sort) String() string {
+	switch s {
+	case SortNewest:
+		return "newest"
+	case SortOldest:
+		return "oldest"
+	case SortTitle:
+		return "title"
+	default:
+		return "rank"
+	}
+}
+
+// ParseSort parses the name of a sort order, empty is the rank
+func ParseSort(value string) (Sort, error) {
+	if value == "" {
+		return SortRank, nil
+	}
+	for sort := range Sort(numberOfSorts) {
+		if strings.EqualFold(value, sort.String()) {
+			return sort, nil
+		}
+	}
+	return SortRank, fmt.Errorf("invalid sort %q", value)
+}
+
+// sorted returns the tickets in the sort order,
+// the tickets are given in rank order
+func (s Sort) sorted(tickets []ticket.Ticket) []ticket.Ticket {
+	switch s {
+	case SortNewest:
+		return slices.SortedStableFunc(slices.Values(tickets), func(a, b ticket.Ticket) int {
+			return cmp.Compare(b.ID.Number(), a.ID.Number())
+		})
+	case SortOldest:
+		return slices.SortedStableFunc(slices.Values(tickets), func(a, b ticket.Ticket) int {
+			return cmp.Compare(a.ID.Number(), b.ID.Number())
+		})
+	case SortTitle:
+		return slices.SortedStableFunc(slices.Values(tickets), func(a, b ticket.Ticket) int {
+			return strings.Compare(strings.ToLower(string(a.Title)), strings.ToLower(string(b.Title)))
+		})
+	default:
+		return tickets
+	}
+}
+
+func (m Model) Sort() Sort {
+	return m.sort
+}
+
+// SetSort changes the order of the tickets, ranking tickets is only possible
+// when they are sorted by rank
+func (m *Model) SetSort(sort Sort) tea.Cmd {
+	m.sort = sort
+	m.updateTitle()
+	return m.setTickets(m.tickets)
+}
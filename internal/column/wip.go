@@ -0,0 +1,77 @@
+package column
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Kavantix/kantui/internal/confirm"
+	"github.com/Kavantix/kantui/internal/messages"
+	"github.com/Kavantix/kantui/internal/theme"
+	"github.com/Kavantix/kantui/internal/ticket"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SetBoardCounts gives the column the amount of tickets per status on the whole board,
+// which are compared to the limits regardless of the query and swimlanes
+func (m *Model) SetBoardCounts(counts map[ticket.Status]int) {
+	m.boardCounts = counts
+	m.updateTitle()
+}
+
+// updateTitle shows the amount of tickets against the limit and the sort order in the title,
+// the header is colored once the limit is reached
+func (m *Model) updateTitle() {
+	theme := theme.Get()
+	m.list.Title = m.status.ColumnTitle()
+	m.list.Styles.Title = m.titleStyle
+	if limit, ok := ticket.GetWipLimits().Limits[m.status]; ok {
+		count := m.boardCounts[m.status]
+		m.list.Title += fmt.Sprintf(" %d/%d", count, limit)
+		switch {
+		case count > limit:
+			m.list.Styles.Title = m.titleStyle.Background(theme.Error)
+		case count == limit:
+			m.list.Styles.Title = m.titleStyle.Background(theme.Warning)
+		}
+	}
+	if m.sort != SortRank {
+		m.list.Title += " · " + m.sort.String()
+	}
+}
+
+// LimitMove enforces the limit of the status the tickets are moved to,
+// either asking for confirmation or refusing the move when it would exceed the limit
+func (m Model) LimitMove(status ticket.Status, tickets []ticket.Ticket, move tea.Cmd) tea.Cmd {
+	return LimitMove(m.store, status, tickets, move)
+}
+
+// LimitMove enforces the limit of the status for views of the board other than the columns.
+// The limit is checked against the committed tickets when the command runs,
+// so a confirmation that was shown earlier does not use outdated counts.
+func LimitMove(store ticket.Store, status ticket.Status, tickets []ticket.Ticket, move tea.Cmd) tea.Cmd {
+	limits := ticket.GetWipLimits()
+	if _, ok := limits.Limits[status]; !ok || limits.Enforcement == ticket.Warn || move == nil {
+		return move
+	}
+	return func() tea.Msg {
+		err := store.CheckWipLimit(ids(tickets), status)
+		var exceeded *ticket.WipLimitError
+		switch {
+		case errors.As(err, &exceeded) && limits.Enforcement == ticket.Block:
+			return messages.ErrorMsg{
+				Err:          err,
+				FriendlyText: "Wip limit of " + status.ColumnTitle() + " reached",
+			}
+		case errors.As(err, &exceeded):
+			question := fmt.Sprintf("%s has %d of %d tickets, move %s anyway?", status.ColumnTitle(), exceeded.Count, exceeded.Limit, describe(tickets))
+			return confirm.Show(question, move)()
+		case err != nil:
+			return messages.ErrorMsg{
+				Err:          err,
+				FriendlyText: "Failed to check the wip limit",
+				Retry:        LimitMove(store, status, tickets, move),
+			}
+		}
+		return move()
+	}
+}
@@ -0,0 +1,92 @@
+// Package config reads the optional kantui config file
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Kavantix/kantui/internal/xdg"
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the settings of the config file,
+// empty values are left to the defaults
+type Config struct {
+	// Database is the folder the boards are stored in
+	Database string `yaml:"database"`
+	// Board is the name of the board that is opened by default
+	Board string `yaml:"board"`
+	// Theme is the name of the color theme
+	Theme string `yaml:"theme"`
+	// Colors overrides colors of the theme
+	Colors map[string]string `yaml:"colors"`
+	// Keymap is the location of the keymap file
+	Keymap    string    `yaml:"keymap"`
+	Behaviour Behaviour `yaml:"behaviour"`
+	// WipLimits is the maximum amount of tickets per column, keyed by status
+	WipLimits map[string]int `yaml:"wip_limits"`
+}
+
+type Behaviour struct {
+	// ConfirmDelete asks for confirmation before deleting a ticket
+	ConfirmDelete *bool `yaml:"confirm_delete"`
+	// Mouse enables clicking and scrolling
+	Mouse *bool `yaml:"mouse"`
+	// Watch refreshes the board when the database is changed by another process
+	Watch *bool `yaml:"watch"`
+	// WipLimit is what happens when a move exceeds a wip limit, one of warn, confirm or block
+	WipLimit string `yaml:"wip_limit"`
+}
+
+// Dir returns the folder the config files are searched in
+func Dir() string {
+	return filepath.Join(xdg.ConfigHome(), "kantui")
+}
+
+// Find returns the config file in the config folder, empty when there is none
+func Find() string {
+	for _, name := range []string{"config.yaml", "config.yml"} {
+		file := filepath.Join(Dir(), name)
+		if _, err := os.Stat(file); err == nil {
+			return file
+		}
+	}
+	return ""
+}
+
+// Load reads the config file, an empty file name results in an empty config
+func Load(file string) (Config, error) {
+	var config Config
+	if file == "" {
+		return config, nil
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return config, fmt.Errorf("failed to read config: %w", err)
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return config, fmt.Errorf("failed to parse config %s: %w", file, err)
+	}
+	config.Database = ExpandHome(config.Database)
+	config.Keymap = ExpandHome(config.Keymap)
+	return config, nil
+}
+
+// ExpandHome replaces a leading ~ with the home directory of the user
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
@@ -1,8 +1,14 @@
 package confirm
 
 import (
+	"fmt"
+
+	"github.com/Kavantix/kantui/internal/keys"
 	"github.com/Kavantix/kantui/internal/messages"
 	"github.com/Kavantix/kantui/internal/overlay"
+	"github.com/Kavantix/kantui/internal/statusbar"
+	"github.com/Kavantix/kantui/internal/theme"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -14,6 +20,7 @@ type Model struct {
 
 // assert
 var _ overlay.ModalModel = Model{}
+var _ statusbar.Hinter = Model{}
 
 func Show(question string, onConfirm tea.Cmd) tea.Cmd {
 	return func() tea.Msg {
@@ -24,6 +31,12 @@ func Show(question string, onConfirm tea.Cmd) tea.Cmd {
 	}
 }
 
+// KeyHints implements statusbar.Hinter.
+func (m Model) KeyHints() []key.Binding {
+	keyMap := keys.Get().Confirm
+	return []key.Binding{keyMap.Confirm, keyMap.Cancel}
+}
+
 func (m Model) Init() tea.Cmd {
 	return nil
 }
@@ -31,10 +44,11 @@ func (m Model) Init() tea.Cmd {
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "y":
+		keyMap := keys.Get().Confirm
+		switch {
+		case key.Matches(msg, keyMap.Confirm):
 			return m, tea.Batch(m.onConfirm, messages.CloseModal)
-		case "n", "esc":
+		case key.Matches(msg, keyMap.Cancel):
 			return m, messages.CloseModal
 		}
 	}
@@ -43,18 +57,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // Size implements overlay.ModalModel.
 func (m Model) Size() (width int, height int) {
-	styleWidth, styleHeight := confirmStyle.GetFrameSize()
+	styleWidth, styleHeight := confirmStyle().GetFrameSize()
 	content := m.View()
 	return lipgloss.Width(content) + styleWidth, lipgloss.Height(content) + styleHeight
 }
 
-var confirmStyle = lipgloss.NewStyle().
-	Border(lipgloss.RoundedBorder()).
-	BorderForeground(lipgloss.Color("62")).
-	Padding(1, 2)
+func confirmStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Get().Accent).
+		Padding(1, 2)
+}
 
 func (m Model) View() string {
 	content := m.question
-	result := confirmStyle.Render(content)
-	return overlay.Place(4, 0, "Confirm (y/n)", result, false)
+	result := confirmStyle().Render(content)
+	keyMap := keys.Get().Confirm
+	title := fmt.Sprintf("Confirm (%s/%s)", keyMap.Confirm.Help().Key, keyMap.Cancel.Help().Key)
+	return overlay.Place(4, 0, title, result, false)
 }
@@ -1,22 +1,36 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"sync"
 )
 
 type Connection interface {
 	Querier
 	BeginTransaction() (TransactionQuerier, error)
+	// DataVersion changes whenever the database is modified by another connection,
+	// including connections from other processes, but not by the transactions of this connection
+	DataVersion(ctx context.Context) (int64, error)
+	// SearchTickets ranks the tickets that are not archived by how well they
+	// match the full text query
+	SearchTickets(ctx context.Context, arg SearchTicketsParams) ([]SearchTicketsRow, error)
 }
 
 type queries struct {
 	Queries
 	db *sql.DB
+
+	// data_version is tracked per connection and does not change for commits
+	// made by the connection itself, so transactions run on the connection that
+	// reads it and only changes made by other connections are detected
+	connMutex sync.Mutex
+	conn      *sql.Conn
 }
 
 func openDb(file string) (*sql.DB, error) {
-	return sql.Open("sqlite", fmt.Sprintf("%s?_txlock=immediate", file))
+	return sql.Open("sqlite", fmt.Sprintf("%s?_txlock=immediate&_pragma=foreign_keys(1)&_pragma=busy_timeout(2000)", file))
 }
 
 func Open(file string) (Connection, error) {
@@ -24,7 +38,35 @@ func Open(file string) (Connection, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to open db: %w", err)
 	}
-	return &queries{*New(db), db}, nil
+	return &queries{Queries: *New(db), db: db}, nil
+}
+
+// connection returns the connection used for transactions and data_version,
+// the caller should hold the connMutex
+func (q *queries) connection(ctx context.Context) (*sql.Conn, error) {
+	if q.conn == nil {
+		conn, err := q.db.Conn(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open connection: %w", err)
+		}
+		q.conn = conn
+	}
+	return q.conn, nil
+}
+
+func (q *queries) DataVersion(ctx context.Context) (int64, error) {
+	q.connMutex.Lock()
+	defer q.connMutex.Unlock()
+	conn, err := q.connection(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var version int64
+	err = conn.QueryRowContext(ctx, "pragma data_version").Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read data version: %w", err)
+	}
+	return version, nil
 }
 
 type TransactionQuerier interface {
@@ -36,20 +78,33 @@ type TransactionQuerier interface {
 type transactionQueries struct {
 	Queries
 	tx *sql.Tx
+	// release gives the connection back once the transaction is done
+	release func()
 }
 
 func (t *transactionQueries) Commit() error {
+	defer t.release()
 	return t.tx.Commit()
 }
 
 func (t *transactionQueries) Rollback() error {
+	defer t.release()
 	return t.tx.Rollback()
 }
 
+// BeginTransaction starts a transaction on the connection that reads the data version,
+// which is held until the transaction is committed or rolled back
 func (q *queries) BeginTransaction() (TransactionQuerier, error) {
-	tx, err := q.db.Begin()
+	q.connMutex.Lock()
+	conn, err := q.connection(context.Background())
+	if err != nil {
+		q.connMutex.Unlock()
+		return nil, err
+	}
+	tx, err := conn.BeginTx(context.Background(), nil)
 	if err != nil {
+		q.connMutex.Unlock()
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	return &transactionQueries{*q.WithTx(tx), tx}, nil
+	return &transactionQueries{*q.WithTx(tx), tx, sync.OnceFunc(q.connMutex.Unlock)}, nil
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
 
 	"github.com/pressly/goose/v3"
 	_ "modernc.org/sqlite"
@@ -38,6 +39,9 @@ func (g gooseLogger) Printf(format string, v ...any) {
 }
 
 func Migrate(file string, remigrateCount int) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return fmt.Errorf("failed to create database folder: %w", err)
+	}
 	db, err := openDb(file)
 	if err != nil {
 		return fmt.Errorf("failed to open database connection: %w", err)
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+create table ticket_labels (
+  ticket_id integer not null references tickets(id) on delete cascade,
+  label     text not null,
+  primary key (ticket_id, label)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists ticket_labels;
+-- +goose StatementEnd
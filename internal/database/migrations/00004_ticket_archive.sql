@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table tickets add column archived_at datetime;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+alter table tickets drop column archived_at;
+-- +goose StatementEnd
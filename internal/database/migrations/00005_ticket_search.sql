@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+create virtual table ticket_search using fts5 (
+  ticket_id unindexed,
+  key,
+  title,
+  description,
+  labels,
+  tokenize = 'unicode61 remove_diacritics 2'
+);
+
+insert into ticket_search (rowid, ticket_id, key, title, description, labels)
+select
+  id, id, 'TK-' || id, title, coalesce(description, ''),
+  coalesce((select group_concat(label, ' ') from ticket_labels where ticket_id = tickets.id), '')
+from tickets;
+
+create trigger ticket_search_insert after insert on tickets begin
+  insert into ticket_search (rowid, ticket_id, key, title, description, labels)
+  values (new.id, new.id, 'TK-' || new.id, new.title, coalesce(new.description, ''), '');
+end;
+
+create trigger ticket_search_update after update of title, description on tickets begin
+  update ticket_search
+  set title = new.title, description = coalesce(new.description, '')
+  where rowid = new.id;
+end;
+
+create trigger ticket_search_delete after delete on tickets begin
+  delete from ticket_search where rowid = old.id;
+end;
+
+create trigger ticket_search_label_insert after insert on ticket_labels begin
+  update ticket_search
+  set labels = (select group_concat(label, ' ') from ticket_labels where ticket_id = new.ticket_id)
+  where rowid = new.ticket_id;
+end;
+
+create trigger ticket_search_label_delete after delete on ticket_labels begin
+  update ticket_search
+  set labels = coalesce((select group_concat(label, ' ') from ticket_labels where ticket_id = old.ticket_id), '')
+  where rowid = old.ticket_id;
+end;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop trigger if exists ticket_search_label_delete;
+drop trigger if exists ticket_search_label_insert;
+drop trigger if exists ticket_search_delete;
+drop trigger if exists ticket_search_update;
+drop trigger if exists ticket_search_insert;
+drop table if exists ticket_search;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+create table views (
+  id     integer primary key autoincrement,
+  name   text not null unique,
+  query  text not null default '',
+  -- layout is a json object with the sort order and visibility of each column
+  layout text not null default '{}'
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists views;
+-- +goose StatementEnd
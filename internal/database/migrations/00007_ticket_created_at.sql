@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Existing tickets keep an unknown creation time
+alter table tickets add column created_at datetime;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+create trigger tickets_created_at after insert on tickets
+when new.created_at is null
+begin
+  update tickets set created_at = current_timestamp where id = new.id;
+end;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop trigger if exists tickets_created_at;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+alter table tickets drop column created_at;
+-- +goose StatementEnd
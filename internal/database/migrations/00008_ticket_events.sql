@@ -0,0 +1,92 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The history of tickets, recorded by triggers so changes made by other
+-- processes and older versions are included as well
+create table ticket_events (
+  id         integer primary key autoincrement,
+  ticket_id  integer not null references tickets(id) on delete cascade,
+  kind       text not null,
+  value      text,
+  created_at datetime not null default current_timestamp
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+create index ticket_events_ticket on ticket_events (ticket_id, id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+create trigger ticket_events_created after insert on tickets
+begin
+  insert into ticket_events (ticket_id, kind, value) values (new.id, 'created', new.status);
+end;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+create trigger ticket_events_moved after update of status on tickets
+when old.status is not new.status
+begin
+  insert into ticket_events (ticket_id, kind, value) values (new.id, 'moved', new.status);
+end;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+create trigger ticket_events_edited after update of title, description on tickets
+when old.title is not new.title or old.description is not new.description
+begin
+  insert into ticket_events (ticket_id, kind) values (new.id, 'edited');
+end;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+create trigger ticket_events_archived after update of archived_at on tickets
+when old.archived_at is null and new.archived_at is not null
+begin
+  insert into ticket_events (ticket_id, kind) values (new.id, 'archived');
+end;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+create trigger ticket_events_labeled after insert on ticket_labels
+begin
+  insert into ticket_events (ticket_id, kind, value) values (new.ticket_id, 'labeled', new.label);
+end;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Labels are also removed when the ticket is deleted, which is not recorded
+create trigger ticket_events_unlabeled after delete on ticket_labels
+when exists (select 1 from tickets where id = old.ticket_id)
+begin
+  insert into ticket_events (ticket_id, kind, value) values (old.ticket_id, 'unlabeled', old.label);
+end;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop trigger if exists ticket_events_unlabeled;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+drop trigger if exists ticket_events_labeled;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+drop trigger if exists ticket_events_archived;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+drop trigger if exists ticket_events_edited;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+drop trigger if exists ticket_events_moved;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+drop trigger if exists ticket_events_created;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+drop table if exists ticket_events;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+create trigger ticket_events_unarchived after update of archived_at on tickets
+when old.archived_at is not null and new.archived_at is null
+begin
+  insert into ticket_events (ticket_id, kind) values (new.id, 'unarchived');
+end;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop trigger if exists ticket_events_unarchived;
+-- +goose StatementEnd
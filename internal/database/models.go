@@ -6,6 +6,7 @@ package database
 
 import (
 	"database/sql"
+	"time"
 )
 
 type Ticket struct {
@@ -14,4 +15,34 @@ type Ticket struct {
 	Title       string
 	Description sql.NullString
 	Rank        int64
+	ArchivedAt  sql.NullTime
+	CreatedAt   sql.NullTime
+}
+
+type TicketEvent struct {
+	ID        int64
+	TicketID  int64
+	Kind      string
+	Value     sql.NullString
+	CreatedAt time.Time
+}
+
+type TicketLabel struct {
+	TicketID int64
+	Label    string
+}
+
+type TicketSearch struct {
+	TicketID    string
+	Key         string
+	Title       string
+	Description string
+	Labels      string
+}
+
+type View struct {
+	ID     int64
+	Name   string
+	Query  string
+	Layout string
 }
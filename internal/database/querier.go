@@ -10,9 +10,19 @@ import (
 
 type Querier interface {
 	AddTicket(ctx context.Context, arg AddTicketParams) (AddTicketRow, error)
+	AddTicketLabel(ctx context.Context, arg AddTicketLabelParams) error
+	ArchiveTicket(ctx context.Context, id int64) error
 	DeleteTicket(ctx context.Context, id int64) error
+	DeleteView(ctx context.Context, name string) error
+	GetArchivedTickets(ctx context.Context) ([]Ticket, error)
 	GetTicketById(ctx context.Context, id int64) (Ticket, error)
+	GetTicketEvents(ctx context.Context, ticketID int64) ([]TicketEvent, error)
+	GetTicketLabels(ctx context.Context) ([]TicketLabel, error)
 	GetTickets(ctx context.Context) ([]Ticket, error)
+	GetViews(ctx context.Context) ([]View, error)
+	RemoveTicketLabel(ctx context.Context, arg RemoveTicketLabelParams) error
+	SaveView(ctx context.Context, arg SaveViewParams) error
+	UnarchiveTicket(ctx context.Context, id int64) (int64, error)
 	UpdateRank(ctx context.Context, arg UpdateRankParams) error
 	UpdateStatus(ctx context.Context, arg UpdateStatusParams) error
 	UpdateTicketContent(ctx context.Context, arg UpdateTicketContentParams) error
@@ -4,6 +4,7 @@ WHERE id = @id LIMIT 1;
 
 -- name: GetTickets :many
 SELECT * FROM tickets
+where archived_at is null
 order by rank, id;
 
 -- name: AddTicket :one
@@ -35,3 +36,57 @@ where id = @id;
 -- name: DeleteTicket :exec
 delete from tickets
 where id = @id;
+
+-- name: ArchiveTicket :exec
+update tickets
+set archived_at = current_timestamp
+where id = @id;
+
+-- name: GetArchivedTickets :many
+SELECT * FROM tickets
+where archived_at is not null
+order by archived_at desc, id;
+
+-- name: UnarchiveTicket :execrows
+update tickets
+set archived_at = null
+where id = @id and archived_at is not null;
+
+-- name: GetTicketLabels :many
+select * from ticket_labels
+order by ticket_id, label;
+
+-- name: AddTicketLabel :exec
+insert or ignore into ticket_labels (
+  ticket_id, label
+)
+values (
+  @ticket_id, @label
+);
+
+-- name: RemoveTicketLabel :exec
+delete from ticket_labels
+where ticket_id = @ticket_id and label = @label;
+
+-- name: GetViews :many
+select * from views
+order by id;
+
+-- name: SaveView :exec
+insert into views (
+  name, query, layout
+)
+values (
+  @name, @query, @layout
+)
+on conflict (name) do update
+set query = excluded.query, layout = excluded.layout;
+
+-- name: DeleteView :exec
+delete from views
+where name = @name;
+
+-- name: GetTicketEvents :many
+select * from ticket_events
+where ticket_id = @ticket_id
+order by id;
@@ -37,6 +37,36 @@ func (q *Queries) AddTicket(ctx context.Context, arg AddTicketParams) (AddTicket
 	return i, err
 }
 
+const addTicketLabel = `-- name: AddTicketLabel :exec
+insert or ignore into ticket_labels (
+  ticket_id, label
+)
+values (
+  ?1, ?2
+)
+`
+
+type AddTicketLabelParams struct {
+	TicketID int64
+	Label    string
+}
+
+func (q *Queries) AddTicketLabel(ctx context.Context, arg AddTicketLabelParams) error {
+	_, err := q.db.ExecContext(ctx, addTicketLabel, arg.TicketID, arg.Label)
+	return err
+}
+
+const archiveTicket = `-- name: ArchiveTicket :exec
+update tickets
+set archived_at = current_timestamp
+where id = ?1
+`
+
+func (q *Queries) ArchiveTicket(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, archiveTicket, id)
+	return err
+}
+
 const deleteTicket = `-- name: DeleteTicket :exec
 delete from tickets
 where id = ?1
@@ -47,8 +77,55 @@ func (q *Queries) DeleteTicket(ctx context.Context, id int64) error {
 	return err
 }
 
+const deleteView = `-- name: DeleteView :exec
+delete from views
+where name = ?1
+`
+
+func (q *Queries) DeleteView(ctx context.Context, name string) error {
+	_, err := q.db.ExecContext(ctx, deleteView, name)
+	return err
+}
+
+const getArchivedTickets = `-- name: GetArchivedTickets :many
+SELECT id, status, title, description, rank, archived_at, created_at FROM tickets
+where archived_at is not null
+order by archived_at desc, id
+`
+
+func (q *Queries) GetArchivedTickets(ctx context.Context) ([]Ticket, error) {
+	rows, err := q.db.QueryContext(ctx, getArchivedTickets)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Ticket
+	for rows.Next() {
+		var i Ticket
+		if err := rows.Scan(
+			&i.ID,
+			&i.Status,
+			&i.Title,
+			&i.Description,
+			&i.Rank,
+			&i.ArchivedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTicketById = `-- name: GetTicketById :one
-SELECT id, status, title, description, rank FROM tickets
+SELECT id, status, title, description, rank, archived_at, created_at FROM tickets
 WHERE id = ?1 LIMIT 1
 `
 
@@ -61,12 +138,78 @@ func (q *Queries) GetTicketById(ctx context.Context, id int64) (Ticket, error) {
 		&i.Title,
 		&i.Description,
 		&i.Rank,
+		&i.ArchivedAt,
+		&i.CreatedAt,
 	)
 	return i, err
 }
 
+const getTicketEvents = `-- name: GetTicketEvents :many
+select id, ticket_id, kind, value, created_at from ticket_events
+where ticket_id = ?1
+order by id
+`
+
+func (q *Queries) GetTicketEvents(ctx context.Context, ticketID int64) ([]TicketEvent, error) {
+	rows, err := q.db.QueryContext(ctx, getTicketEvents, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TicketEvent
+	for rows.Next() {
+		var i TicketEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.TicketID,
+			&i.Kind,
+			&i.Value,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTicketLabels = `-- name: GetTicketLabels :many
+select ticket_id, label from ticket_labels
+order by ticket_id, label
+`
+
+func (q *Queries) GetTicketLabels(ctx context.Context) ([]TicketLabel, error) {
+	rows, err := q.db.QueryContext(ctx, getTicketLabels)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TicketLabel
+	for rows.Next() {
+		var i TicketLabel
+		if err := rows.Scan(&i.TicketID, &i.Label); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTickets = `-- name: GetTickets :many
-SELECT id, status, title, description, rank FROM tickets
+SELECT id, status, title, description, rank, archived_at, created_at FROM tickets
+where archived_at is null
 order by rank, id
 `
 
@@ -85,6 +228,41 @@ func (q *Queries) GetTickets(ctx context.Context) ([]Ticket, error) {
 			&i.Title,
 			&i.Description,
 			&i.Rank,
+			&i.ArchivedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getViews = `-- name: GetViews :many
+select id, name, "query", layout from views
+order by id
+`
+
+func (q *Queries) GetViews(ctx context.Context) ([]View, error) {
+	rows, err := q.db.QueryContext(ctx, getViews)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []View
+	for rows.Next() {
+		var i View
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Query,
+			&i.Layout,
 		); err != nil {
 			return nil, err
 		}
@@ -99,6 +277,57 @@ func (q *Queries) GetTickets(ctx context.Context) ([]Ticket, error) {
 	return items, nil
 }
 
+const removeTicketLabel = `-- name: RemoveTicketLabel :exec
+delete from ticket_labels
+where ticket_id = ?1 and label = ?2
+`
+
+type RemoveTicketLabelParams struct {
+	TicketID int64
+	Label    string
+}
+
+func (q *Queries) RemoveTicketLabel(ctx context.Context, arg RemoveTicketLabelParams) error {
+	_, err := q.db.ExecContext(ctx, removeTicketLabel, arg.TicketID, arg.Label)
+	return err
+}
+
+const saveView = `-- name: SaveView :exec
+insert into views (
+  name, query, layout
+)
+values (
+  ?1, ?2, ?3
+)
+on conflict (name) do update
+set query = excluded.query, layout = excluded.layout
+`
+
+type SaveViewParams struct {
+	Name   string
+	Query  string
+	Layout string
+}
+
+func (q *Queries) SaveView(ctx context.Context, arg SaveViewParams) error {
+	_, err := q.db.ExecContext(ctx, saveView, arg.Name, arg.Query, arg.Layout)
+	return err
+}
+
+const unarchiveTicket = `-- name: UnarchiveTicket :execrows
+update tickets
+set archived_at = null
+where id = ?1 and archived_at is not null
+`
+
+func (q *Queries) UnarchiveTicket(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, unarchiveTicket, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateRank = `-- name: UpdateRank :exec
 update tickets
 set rank = ?1
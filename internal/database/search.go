@@ -0,0 +1,59 @@
+package database
+
+import (
+	"context"
+	"fmt"
+)
+
+// SnippetStart and SnippetEnd surround the matched terms in a search snippet
+const (
+	SnippetStart = "\x02"
+	SnippetEnd   = "\x03"
+)
+
+// searchTickets is written by hand because sqlc can not resolve the hidden
+// column that fts5 matches against, which has the name of the table.
+// The key and title weigh the most in the ranking, then the labels.
+const searchTickets = `
+select
+  ticket_search.rowid,
+  bm25(ticket_search, 0, 10.0, 10.0, 1.0, 5.0) as score,
+  snippet(ticket_search, -1, char(2), char(3), '…', 12) as snippet
+from ticket_search
+join tickets on tickets.id = ticket_search.rowid
+where ticket_search match ?1 and tickets.archived_at is null
+order by score
+limit ?2
+`
+
+type SearchTicketsParams struct {
+	Query      string
+	MaxResults int64
+}
+
+type SearchTicketsRow struct {
+	TicketID int64
+	// Score is lower for better matches
+	Score   float64
+	Snippet string
+}
+
+func (q *queries) SearchTickets(ctx context.Context, arg SearchTicketsParams) ([]SearchTicketsRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchTickets, arg.Query, arg.MaxResults)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tickets: %w", err)
+	}
+	defer rows.Close()
+	var items []SearchTicketsRow
+	for rows.Next() {
+		var i SearchTicketsRow
+		if err := rows.Scan(&i.TicketID, &i.Score, &i.Snippet); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	return items, rows.Err()
+}
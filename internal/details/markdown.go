@@ -0,0 +1,124 @@
+package details
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Kavantix/kantui/internal/theme"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var (
+	headingPattern = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	taskPattern    = regexp.MustCompile(`^([-*+])\s+\[([ xX])\]\s+(.*)$`)
+	bulletPattern  = regexp.MustCompile(`^([-*+])\s+(.*)$`)
+	numberPattern  = regexp.MustCompile(`^(\d+[.)])\s+(.*)$`)
+	rulePattern    = regexp.MustCompile(`^([-*_])(\s*[-*_]){2,}$`)
+
+	codePattern   = regexp.MustCompile("`([^`]+)`")
+	boldPattern   = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	italicPattern = regexp.MustCompile(`\*([^*\s][^*]*)\*|\b_([^_\s][^_]*)_\b`)
+	linkPattern   = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+)
+
+// renderMarkdown renders the common parts of markdown used in descriptions:
+// headings, lists, task lists, quotes, code and emphasis, wrapped to the width
+func renderMarkdown(text string, width int) string {
+	theme := theme.Get()
+	width = max(1, width)
+	var lines []string
+	inCode := false
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			// Code keeps its layout, so lines that are too long are cut off
+			code := ansi.Truncate(strings.ReplaceAll(line, "\t", "  "), width-2, "…")
+			lines = append(lines, lipgloss.NewStyle().Foreground(theme.Muted).Render("  "+code))
+			continue
+		}
+		indent := strings.Repeat(" ", min(len(line)-len(strings.TrimLeft(line, " \t")), width/2))
+		if matches := headingPattern.FindStringSubmatch(trimmed); matches != nil {
+			style := lipgloss.NewStyle().Foreground(theme.Highlight).Bold(true)
+			if len(matches[1]) == 1 {
+				style = style.Underline(true)
+			}
+			lines = append(lines, wrap(style.Render(inline(matches[2])), "", "", width))
+			continue
+		}
+		if matches := taskPattern.FindStringSubmatch(trimmed); matches != nil {
+			box, text := "☐ ", inline(matches[3])
+			if matches[2] != " " {
+				box = lipgloss.NewStyle().Foreground(theme.Done).Render("☑ ")
+				text = lipgloss.NewStyle().Foreground(theme.Muted).Strikethrough(true).Render(ansi.Strip(text))
+			}
+			lines = append(lines, wrap(text, indent+box, indent+"  ", width))
+			continue
+		}
+		if matches := bulletPattern.FindStringSubmatch(trimmed); matches != nil && !rulePattern.MatchString(trimmed) {
+			lines = append(lines, wrap(inline(matches[2]), indent+"• ", indent+"  ", width))
+			continue
+		}
+		if matches := numberPattern.FindStringSubmatch(trimmed); matches != nil {
+			number := matches[1] + " "
+			lines = append(lines, wrap(inline(matches[2]), indent+number, indent+strings.Repeat(" ", len(number)), width))
+			continue
+		}
+		if quote, ok := strings.CutPrefix(trimmed, ">"); ok {
+			bar := lipgloss.NewStyle().Foreground(theme.Muted).Render("│ ")
+			text := lipgloss.NewStyle().Foreground(theme.Muted).Italic(true).Render(inline(strings.TrimSpace(quote)))
+			lines = append(lines, wrap(text, bar, bar, width))
+			continue
+		}
+		if rulePattern.MatchString(trimmed) {
+			lines = append(lines, lipgloss.NewStyle().Foreground(theme.Border).Render(strings.Repeat("─", width)))
+			continue
+		}
+		lines = append(lines, wrap(inline(trimmed), indent, indent, width))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// inline renders the code, emphasis and links within a line
+func inline(text string) string {
+	theme := theme.Get()
+	// Code is replaced by placeholders first, so its contents are not styled as emphasis
+	var code []string
+	text = codePattern.ReplaceAllStringFunc(text, func(match string) string {
+		code = append(code, lipgloss.NewStyle().Foreground(theme.Label).Render(match[1:len(match)-1]))
+		return "\x00" + strconv.Itoa(len(code)-1) + "\x00"
+	})
+	text = linkPattern.ReplaceAllStringFunc(text, func(match string) string {
+		parts := linkPattern.FindStringSubmatch(match)
+		return lipgloss.NewStyle().Foreground(theme.Highlight).Underline(true).Render(parts[1])
+	})
+	text = boldPattern.ReplaceAllStringFunc(text, func(match string) string {
+		return lipgloss.NewStyle().Bold(true).Render(match[2 : len(match)-2])
+	})
+	text = italicPattern.ReplaceAllStringFunc(text, func(match string) string {
+		return lipgloss.NewStyle().Italic(true).Render(match[1 : len(match)-1])
+	})
+	for i, rendered := range code {
+		text = strings.Replace(text, "\x00"+strconv.Itoa(i)+"\x00", rendered, 1)
+	}
+	return text
+}
+
+// wrap wraps the text to the width, starting with the prefix and indenting the following lines
+func wrap(text, prefix, indent string, width int) string {
+	available := max(1, width-ansi.StringWidth(prefix))
+	lines := strings.Split(ansi.Wrap(text, available, ""), "\n")
+	for i, line := range lines {
+		if i == 0 {
+			lines[i] = prefix + line
+		} else {
+			lines[i] = indent + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
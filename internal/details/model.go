@@ -0,0 +1,171 @@
+// Package details shows all information of a single ticket next to the board,
+// without the risk of editing it
+package details
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Kavantix/kantui/internal/theme"
+	"github.com/Kavantix/kantui/internal/ticket"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// timeFormat is how the creation time and the history are shown
+const timeFormat = "2006-01-02 15:04"
+
+type Model struct {
+	store ticket.Store
+
+	ticket ticket.Ticket
+	// shown is false when there is no ticket under the cursor
+	shown bool
+	// events are the history of the ticket, nil until it is loaded
+	events []ticket.Event
+
+	viewport viewport.Model
+	width    int
+	height   int
+}
+
+func New(store ticket.Store) Model {
+	content := viewport.New(0, 0)
+	// Scrolling is bound in the keymap of the board
+	content.KeyMap = viewport.KeyMap{}
+	return Model{
+		store:    store,
+		viewport: content,
+	}
+}
+
+// SetTicket shows the ticket, its history is loaded when another ticket is shown
+// or when the tickets changed
+func (m *Model) SetTicket(t ticket.Ticket, ok bool, changed bool) tea.Cmd {
+	same := ok == m.shown && t.ID == m.ticket.ID
+	if same && !changed {
+		return nil
+	}
+	m.ticket = t
+	m.shown = ok
+	if !same {
+		m.events = nil
+		m.viewport.GotoTop()
+	}
+	m.render()
+	if !ok {
+		return nil
+	}
+	return m.store.History(t.ID)
+}
+
+func style() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Get().Border).
+		Padding(0, 1)
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	frameWidth, frameHeight := style().GetFrameSize()
+	m.viewport.Width = max(0, width-frameWidth)
+	m.viewport.Height = max(0, height-frameHeight)
+	m.render()
+}
+
+// ScrollUp scrolls half a page up
+func (m *Model) ScrollUp() {
+	m.viewport.HalfPageUp()
+}
+
+// ScrollDown scrolls half a page down
+func (m *Model) ScrollDown() {
+	m.viewport.HalfPageDown()
+}
+
+// render lays out the ticket for the width of the viewport
+func (m *Model) render() {
+	theme := theme.Get()
+	width := m.viewport.Width
+	if !m.shown {
+		m.viewport.SetContent(lipgloss.NewStyle().Foreground(theme.Muted).Render("No ticket selected"))
+		return
+	}
+	t := m.ticket
+	muted := lipgloss.NewStyle().Foreground(theme.Muted)
+	heading := lipgloss.NewStyle().Foreground(theme.Muted).Bold(true)
+	field := func(name, value string) string {
+		return wrap(value, muted.Render(fmt.Sprintf("%-9s", name)), strings.Repeat(" ", 9), width)
+	}
+
+	labels := make([]string, len(t.Labels))
+	for i, label := range t.Labels {
+		labels[i] = "#" + string(label)
+	}
+	created := muted.Render("unknown")
+	if !t.CreatedAt.IsZero() {
+		created = t.CreatedAt.Local().Format(timeFormat)
+	}
+	lines := []string{
+		wrap(lipgloss.NewStyle().Foreground(theme.Title).Bold(true).Render(string(t.Title)), ticket.IdStyle().Render(t.ID.String())+" ", "", width),
+		"",
+		field("Status", t.Status.ColumnTitle()),
+		field("Labels", ticket.LabelStyle().Render(strings.Join(labels, " "))),
+		field("Created", created),
+		"",
+	}
+	if strings.TrimSpace(string(t.Description)) == "" {
+		lines = append(lines, muted.Render("No description"))
+	} else {
+		lines = append(lines, renderMarkdown(strings.TrimSpace(string(t.Description)), width))
+	}
+
+	lines = append(lines, "", heading.Render("HISTORY"))
+	switch {
+	case m.events == nil:
+		lines = append(lines, muted.Render("Loading…"))
+	case len(m.events) == 0:
+		lines = append(lines, muted.Render("No changes recorded"))
+	}
+	for _, event := range m.events {
+		lines = append(lines, wrap(event.String(), muted.Render(event.At.Local().Format(timeFormat))+"  ", strings.Repeat(" ", len(timeFormat)+2), width))
+	}
+	m.viewport.SetContent(strings.Join(lines, "\n"))
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ticket.HistoryMsg:
+		if !m.shown || msg.ID != m.ticket.ID {
+			// The history arrived after another ticket was selected
+			return m, nil
+		}
+		m.events = msg.Events
+		if m.events == nil {
+			m.events = []ticket.Event{}
+		}
+		m.render()
+		return m, nil
+	case tea.MouseMsg:
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m Model) View() string {
+	frameWidth, frameHeight := style().GetFrameSize()
+	return style().
+		Width(max(0, m.width-frameWidth+style().GetHorizontalPadding())).
+		Height(max(0, m.height-frameHeight)).
+		MaxHeight(m.height).
+		Render(m.viewport.View())
+}
@@ -0,0 +1,93 @@
+// Package exporter writes tickets in formats used by other task workflows
+package exporter
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/Kavantix/kantui/internal/ticket"
+)
+
+// TodoTxt writes every ticket as a todo.txt task, completing the tickets in
+// the last column. A priority:A label is written as the priority (A), which
+// completed tasks keep as a pri:A tag. Labels starting with @ are written as
+// contexts, labels like due:2024-05-01 as tags and other labels as projects.
+// Descriptions are not part of the format and are omitted.
+func TodoTxt(w io.Writer, tickets []ticket.Ticket) error {
+	writer := bufio.NewWriter(w)
+	for _, t := range tickets {
+		priority := t.Priority()
+		isLetter := len(priority) == 1 && unicode.IsLetter(rune(priority[0]))
+		priority = strings.ToUpper(priority)
+		switch {
+		case isCompleted(t):
+			writer.WriteString("x ")
+		case isLetter:
+			writer.WriteString("(" + priority + ") ")
+		}
+		writer.WriteString(singleLine(string(t.Title)))
+		for _, label := range t.Labels {
+			if isLetter && strings.EqualFold(string(label), ticket.PriorityPrefix+priority) {
+				if isCompleted(t) {
+					writer.WriteString(" pri:" + priority)
+				}
+				continue
+			}
+			writer.WriteRune(' ')
+			if !strings.HasPrefix(string(label), "@") && !strings.Contains(string(label), ":") {
+				writer.WriteRune('+')
+			}
+			writer.WriteString(string(label))
+		}
+		writer.WriteRune('\n')
+	}
+	return writer.Flush()
+}
+
+// Markdown writes a heading per column with its tickets as a task list,
+// checking the tickets in the last column.
+// Descriptions are indented below their task.
+func Markdown(w io.Writer, tickets []ticket.Ticket) error {
+	writer := bufio.NewWriter(w)
+	for i, status := range ticket.Statusses {
+		if i > 0 {
+			writer.WriteRune('\n')
+		}
+		writer.WriteString("## ")
+		writer.WriteString(status.ColumnTitle())
+		writer.WriteString("\n\n")
+		for _, t := range tickets {
+			if t.Status != status {
+				continue
+			}
+			if isCompleted(t) {
+				writer.WriteString("- [x] ")
+			} else {
+				writer.WriteString("- [ ] ")
+			}
+			writer.WriteString(singleLine(string(t.Title)))
+			writer.WriteRune('\n')
+			if t.Description == "" {
+				continue
+			}
+			for line := range strings.SplitSeq(string(t.Description), "\n") {
+				if line != "" {
+					writer.WriteString("  ")
+					writer.WriteString(line)
+				}
+				writer.WriteRune('\n')
+			}
+		}
+	}
+	return writer.Flush()
+}
+
+func isCompleted(t ticket.Ticket) bool {
+	return t.Status == ticket.Statusses[len(ticket.Statusses)-1]
+}
+
+func singleLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,121 @@
+package exporter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Kavantix/kantui/internal/importer"
+	"github.com/Kavantix/kantui/internal/ticket"
+)
+
+func TestTodoTxt(t *testing.T) {
+	tests := []struct {
+		name   string
+		ticket ticket.Ticket
+		want   string
+	}{
+		{"plain", ticket.Ticket{Status: ticket.Todo, Title: "Call mom"}, "Call mom\n"},
+		{"in progress is open", ticket.Ticket{Status: ticket.InProgress, Title: "Call mom"}, "Call mom\n"},
+		{"completed", ticket.Ticket{Status: ticket.Done, Title: "Call mom"}, "x Call mom\n"},
+		{"multiline title", ticket.Ticket{Status: ticket.Todo, Title: "Call\n  mom"}, "Call mom\n"},
+		{"description is omitted", ticket.Ticket{Status: ticket.Todo, Title: "Call mom", Description: "About dinner"}, "Call mom\n"},
+		{
+			name:   "labels",
+			ticket: ticket.Ticket{Status: ticket.Todo, Title: "Fix bike", Labels: []ticket.TicketLabel{"garage", "@home", "due:2024-06-01"}},
+			want:   "Fix bike +garage @home due:2024-06-01\n",
+		},
+		{
+			name:   "letter priority",
+			ticket: ticket.Ticket{Status: ticket.Todo, Title: "Pay taxes", Labels: []ticket.TicketLabel{"Priority:a", "money"}},
+			want:   "(A) Pay taxes +money\n",
+		},
+		{
+			name:   "completed letter priority",
+			ticket: ticket.Ticket{Status: ticket.Done, Title: "Pay taxes", Labels: []ticket.TicketLabel{"priority:A"}},
+			want:   "x Pay taxes pri:A\n",
+		},
+		{
+			name:   "other priorities are tags",
+			ticket: ticket.Ticket{Status: ticket.Todo, Title: "Pay taxes", Labels: []ticket.TicketLabel{"priority:high"}},
+			want:   "Pay taxes priority:high\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var out strings.Builder
+			if err := TodoTxt(&out, []ticket.Ticket{test.ticket}); err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != test.want {
+				t.Errorf("TodoTxt wrote %q, want %q", out.String(), test.want)
+			}
+		})
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	tickets := []ticket.Ticket{
+		{Status: ticket.Done, Title: "Release"},
+		{Status: ticket.Todo, Title: "Write docs", Description: "First line\n\n  indented"},
+		{Status: ticket.Todo, Title: "Next"},
+	}
+	var out strings.Builder
+	if err := Markdown(&out, tickets); err != nil {
+		t.Fatal(err)
+	}
+	want := "## TODO\n\n" +
+		"- [ ] Write docs\n  First line\n\n    indented\n" +
+		"- [ ] Next\n" +
+		"\n## IN PROGRESS\n\n" +
+		"\n## DONE\n\n" +
+		"- [x] Release\n"
+	if out.String() != want {
+		t.Errorf("Markdown wrote\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		export  func(*strings.Builder, []ticket.Ticket) error
+		parse   func(*strings.Reader) ([]ticket.Ticket, error)
+		tickets []ticket.Ticket
+	}{
+		{
+			name:   "todo.txt",
+			export: func(w *strings.Builder, tickets []ticket.Ticket) error { return TodoTxt(w, tickets) },
+			parse:  func(r *strings.Reader) ([]ticket.Ticket, error) { return importer.TodoTxt(r) },
+			tickets: []ticket.Ticket{
+				{Status: ticket.Todo, Title: "Pay taxes", Labels: []ticket.TicketLabel{"priority:A", "money", "@home", "due:2024-06-01"}},
+				{Status: ticket.Todo, Title: "Call mom"},
+				{Status: ticket.Done, Title: "Fix bike", Labels: []ticket.TicketLabel{"priority:B", "garage"}},
+			},
+		},
+		{
+			name:   "markdown",
+			export: func(w *strings.Builder, tickets []ticket.Ticket) error { return Markdown(w, tickets) },
+			parse:  func(r *strings.Reader) ([]ticket.Ticket, error) { return importer.Markdown(r) },
+			tickets: []ticket.Ticket{
+				{Status: ticket.Todo, Title: "Write docs", Description: "First line\n\n- [ ] nested\n  indented"},
+				{Status: ticket.Todo, Title: "Call mom"},
+				{Status: ticket.Done, Title: "Release", Description: "Shipped"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var out strings.Builder
+			if err := test.export(&out, test.tickets); err != nil {
+				t.Fatal(err)
+			}
+			tickets, err := test.parse(strings.NewReader(out.String()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(tickets, test.tickets) {
+				t.Errorf("round trip through\n%s\nreturned %+v, want %+v", out.String(), tickets, test.tickets)
+			}
+		})
+	}
+}
@@ -1,21 +1,106 @@
 package flags
 
-import "flag"
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
 
+	"github.com/Kavantix/kantui/internal/config"
+	"github.com/Kavantix/kantui/internal/project"
+	"github.com/Kavantix/kantui/internal/xdg"
+)
+
+// Context holds the settings kantui runs with,
+// flags take precedence over environment variables, which take precedence over the board of the project
+// and then the config file
 type Context struct {
 	remigrateCount *int
 	debug          *bool
 	dbFolder       *string
+	board          *string
+	theme          *string
+	keymap         *string
+	configFile     *string
+	confirmDelete  *bool
+	mouse          *bool
+	watch          *bool
+	wipLimit       *string
+
+	config config.Config
+	// project is the folder of the board found by walking up from the working directory
+	project string
 }
 
-func New() *Context {
+func New() (*Context, error) {
 	c := Context{
 		remigrateCount: flag.Int("remigrate", 0, "the amount of migrations to down before running up migrations"),
 		debug:          flag.Bool("debug", false, "turns on debug logging"),
-		dbFolder:       flag.String("db", "", "location where the database is stored"),
+		dbFolder:       flag.String("db", "", "location where the database is stored, defaults to $XDG_DATA_HOME/kantui ($KANTUI_DB)"),
+		board:          flag.String("board", "", "name of the board to open, defaults to kantui ($KANTUI_BOARD)"),
+		theme:          flag.String("theme", "", "the color theme ($KANTUI_THEME)"),
+		keymap:         flag.String("keymap", "", "location of the keymap file, defaults to $XDG_CONFIG_HOME/kantui/keymap.yaml ($KANTUI_KEYMAP)"),
+		configFile:     flag.String("config", "", "location of the config file, defaults to $XDG_CONFIG_HOME/kantui/config.yaml ($KANTUI_CONFIG)"),
+		confirmDelete:  flag.Bool("confirm-delete", true, "ask before deleting tickets ($KANTUI_CONFIRM_DELETE)"),
+		mouse:          flag.Bool("mouse", true, "enable mouse support ($KANTUI_MOUSE)"),
+		watch:          flag.Bool("watch", true, "show changes made by other processes ($KANTUI_WATCH)"),
+		wipLimit:       flag.String("wip-limit", "", "what happens when a move exceeds a wip limit, one of warn, confirm or block ($KANTUI_WIP_LIMIT)"),
 	}
 	flag.Parse()
-	return &c
+	c.project = project.Find(".")
+
+	file := firstOf(*c.configFile, os.Getenv("KANTUI_CONFIG"), config.Find())
+	var err error
+	c.config, err = config.Load(file)
+	if err != nil {
+		return nil, err
+	}
+	behaviour := &c.config.Behaviour
+	for env, setting := range map[string]**bool{
+		"KANTUI_CONFIRM_DELETE": &behaviour.ConfirmDelete,
+		"KANTUI_MOUSE":          &behaviour.Mouse,
+		"KANTUI_WATCH":          &behaviour.Watch,
+	} {
+		value, ok := os.LookupEnv(env)
+		if !ok {
+			continue
+		}
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %q", env, value)
+		}
+		*setting = &enabled
+	}
+	if value := os.Getenv("KANTUI_WIP_LIMIT"); value != "" {
+		behaviour.WipLimit = value
+	}
+	// Only the flags that are given override the environment and the config file
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "confirm-delete":
+			behaviour.ConfirmDelete = c.confirmDelete
+		case "mouse":
+			behaviour.Mouse = c.mouse
+		case "watch":
+			behaviour.Watch = c.watch
+		case "wip-limit":
+			behaviour.WipLimit = *c.wipLimit
+		}
+	})
+	return &c, nil
+}
+
+// firstOf returns the first value that is set
+func firstOf(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
 }
 
 func (c *Context) RemigrateCount() int {
@@ -27,5 +112,124 @@ func (c *Context) Debug() bool {
 }
 
 func (c *Context) DbFolder() string {
-	return *c.dbFolder
+	if folder := c.configuredDbFolder(); folder != "" {
+		return folder
+	}
+	if c.usesLegacyDb() {
+		return "."
+	}
+	return filepath.Join(xdg.DataHome(), "kantui")
+}
+
+func (c *Context) configuredDbFolder() string {
+	return firstOf(*c.dbFolder, os.Getenv("KANTUI_DB"), c.config.Database)
+}
+
+// usesLegacyDb reports whether the board is read from the working directory,
+// where boards were stored before they moved to the data directory.
+// The old database keeps being used until the board exists in the data directory,
+// so upgrading does not open an empty board.
+func (c *Context) usesLegacyDb() bool {
+	file := c.Board() + ".sqlite3"
+	if _, err := os.Stat(file); err != nil {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(xdg.DataHome(), "kantui", file))
+	return errors.Is(err, fs.ErrNotExist)
+}
+
+// LegacyDbWarning explains how to move the board when the database in the working directory is used,
+// empty otherwise
+func (c *Context) LegacyDbWarning() string {
+	if c.useProject() || c.configuredDbFolder() != "" || !c.usesLegacyDb() {
+		return ""
+	}
+	return fmt.Sprintf(
+		"%s in the working directory is used, boards are now stored in %s. Move it there to open it from any directory",
+		c.DbFile(), filepath.Join(xdg.DataHome(), "kantui"),
+	)
+}
+
+// Board returns the name of the board, the name of the project when it has one
+// and otherwise also the name of its database file
+func (c *Context) Board() string {
+	if c.useProject() {
+		return filepath.Base(c.project)
+	}
+	return firstOf(*c.board, os.Getenv("KANTUI_BOARD"), c.config.Board, "kantui")
+}
+
+// Project returns the folder of the project the board belongs to, empty when using a global board
+func (c *Context) Project() string {
+	if c.useProject() {
+		return c.project
+	}
+	return ""
+}
+
+// useProject reports whether the board of the project is used,
+// explicitly choosing a database or board overrides it
+func (c *Context) useProject() bool {
+	explicit := firstOf(*c.dbFolder, os.Getenv("KANTUI_DB"), *c.board, os.Getenv("KANTUI_BOARD"))
+	return c.project != "" && explicit == ""
+}
+
+func (c *Context) DbFile() string {
+	if c.useProject() {
+		return project.DbFile(c.project)
+	}
+	return filepath.Join(c.DbFolder(), c.Board()+".sqlite3")
+}
+
+// Theme returns the name of the color theme
+func (c *Context) Theme() string {
+	return firstOf(*c.theme, os.Getenv("KANTUI_THEME"), c.config.Theme, "auto")
+}
+
+// Colors returns the colors that override the theme
+func (c *Context) Colors() map[string]string {
+	return c.config.Colors
+}
+
+// Keymap returns the location of the keymap file
+// and whether it was explicitly configured, in which case it must exist
+func (c *Context) Keymap() (file string, explicit bool) {
+	file = firstOf(*c.keymap, os.Getenv("KANTUI_KEYMAP"), c.config.Keymap)
+	if file != "" {
+		return file, true
+	}
+	return filepath.Join(config.Dir(), "keymap.yaml"), false
+}
+
+// ConfirmDelete reports whether deleting a ticket has to be confirmed
+func (c *Context) ConfirmDelete() bool {
+	return enabled(c.config.Behaviour.ConfirmDelete, true)
+}
+
+// Mouse reports whether mouse support is enabled
+func (c *Context) Mouse() bool {
+	return enabled(c.config.Behaviour.Mouse, true)
+}
+
+// Watch reports whether external changes to the database are shown
+func (c *Context) Watch() bool {
+	return enabled(c.config.Behaviour.Watch, true)
+}
+
+// WipLimits returns the limits per status and how they are enforced
+func (c *Context) WipLimits() (limits map[string]int, enforcement string) {
+	return c.config.WipLimits, c.config.Behaviour.WipLimit
+}
+
+func enabled(setting *bool, fallback bool) bool {
+	if setting == nil {
+		return fallback
+	}
+	return *setting
+}
+
+// Args returns the arguments remaining after the flags,
+// the first of which is the command to run
+func (c *Context) Args() []string {
+	return flag.Args()
 }
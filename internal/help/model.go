@@ -0,0 +1,113 @@
+// Package help shows all keybindings in a modal
+package help
+
+import (
+	"strings"
+
+	"github.com/Kavantix/kantui/internal/keys"
+	"github.com/Kavantix/kantui/internal/messages"
+	"github.com/Kavantix/kantui/internal/overlay"
+	"github.com/Kavantix/kantui/internal/statusbar"
+	"github.com/Kavantix/kantui/internal/theme"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type Model struct {
+	width int
+}
+
+// assert
+var _ overlay.ModalModel = Model{}
+var _ overlay.Sizeable = Model{}
+var _ statusbar.Hinter = Model{}
+
+func Show() tea.Msg {
+	return Model{}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if key.Matches(msg, keys.Get().Help.Close) {
+			return m, messages.CloseModal
+		}
+	}
+	return m, nil
+}
+
+// KeyHints implements statusbar.Hinter.
+func (m Model) KeyHints() []key.Binding {
+	return []key.Binding{keys.Get().Help.Close}
+}
+
+// SetSize implements overlay.Sizeable.
+func (m Model) SetSize(width, height int) overlay.ModalModel {
+	m.width = width
+	return m
+}
+
+// Size implements overlay.ModalModel.
+func (m Model) Size() (width int, height int) {
+	content := m.View()
+	return lipgloss.Width(content), lipgloss.Height(content)
+}
+
+func helpStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Get().Accent).
+		Padding(1, 2)
+}
+
+func renderGroup(group keys.Group) string {
+	theme := theme.Get()
+	groupTitleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(theme.Highlight)
+	keyStyle := lipgloss.NewStyle().
+		Foreground(theme.Text)
+	descriptionStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted)
+	keyWidth := 0
+	for _, binding := range group.Bindings {
+		if binding.Enabled() {
+			keyWidth = max(keyWidth, lipgloss.Width(binding.Help().Key))
+		}
+	}
+	lines := []string{groupTitleStyle.Render(group.Title)}
+	for _, binding := range group.Bindings {
+		if !binding.Enabled() {
+			continue
+		}
+		lines = append(lines,
+			keyStyle.Width(keyWidth+2).Render(binding.Help().Key)+
+				descriptionStyle.Render(binding.Help().Desc),
+		)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m Model) View() string {
+	groups := keys.Get().Groups()
+	rendered := make([]string, len(groups))
+	for i, group := range groups {
+		rendered[i] = renderGroup(group)
+	}
+
+	// The column keybindings are the largest group,
+	// so the other groups are stacked next to it when there is room
+	content := lipgloss.JoinVertical(lipgloss.Left, strings.Join(rendered, "\n\n"))
+	side := strings.Join(append(rendered[:1:1], rendered[2:]...), "\n\n")
+	twoColumns := lipgloss.JoinHorizontal(lipgloss.Top, side, "    ", rendered[1])
+	frameWidth, _ := helpStyle().GetFrameSize()
+	if m.width == 0 || lipgloss.Width(twoColumns)+frameWidth <= m.width {
+		content = twoColumns
+	}
+	return overlay.Place(4, 0, "Help", helpStyle().Render(content), false)
+}
@@ -0,0 +1,59 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/Kavantix/kantui/internal/ticket"
+)
+
+type githubIssue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	State  string `json:"state"`
+	URL    string `json:"url"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+// GitHub reads the output of `gh issue list --json number,title,body,state,labels`.
+// Open issues become todo tickets and closed issues become done tickets,
+// ranked in the order the issues were created.
+func GitHub(r io.Reader) ([]ticket.Ticket, error) {
+	var issues []githubIssue
+	if err := json.NewDecoder(r).Decode(&issues); err != nil {
+		return nil, fmt.Errorf("failed to decode github issues: %w", err)
+	}
+	slices.SortStableFunc(issues, func(a, b githubIssue) int { return a.Number - b.Number })
+
+	tickets := make([]ticket.Ticket, 0, len(issues))
+	for _, issue := range issues {
+		status := ticket.Todo
+		if strings.EqualFold(issue.State, "closed") {
+			status = ticket.Done
+		}
+		var labels []ticket.TicketLabel
+		for _, label := range issue.Labels {
+			labels = append(labels, ticket.TicketLabel(label.Name))
+		}
+		description := strings.TrimSpace(strings.ReplaceAll(issue.Body, "\r\n", "\n"))
+		if issue.URL != "" {
+			if description != "" {
+				description += "\n\n"
+			}
+			description += "Imported from " + issue.URL
+		}
+		tickets = append(tickets, ticket.Ticket{
+			Status:      status,
+			Title:       ticket.TicketTitle(strings.TrimSpace(issue.Title)),
+			Description: ticket.TicketDescription(description),
+			Labels:      uniqueLabels(labels),
+		})
+	}
+	return tickets, nil
+}
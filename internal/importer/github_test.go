@@ -0,0 +1,54 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Kavantix/kantui/internal/ticket"
+)
+
+func TestGitHub(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []ticket.Ticket
+	}{
+		{"empty", `[]`, []ticket.Ticket{}},
+		{
+			name: "issues",
+			input: `[
+				{"number": 12, "title": " Crash on start ", "body": "Steps:\r\n1. open\r\n", "state": "OPEN", "url": "https://github.com/o/r/issues/12", "labels": [{"name": "bug"}, {"name": "good first issue"}]},
+				{"number": 3, "title": "Add docs", "body": "", "state": "CLOSED", "labels": []}
+			]`,
+			want: []ticket.Ticket{
+				{Status: ticket.Done, Title: "Add docs"},
+				{
+					Status:      ticket.Todo,
+					Title:       "Crash on start",
+					Description: "Steps:\n1. open\n\nImported from https://github.com/o/r/issues/12",
+					Labels:      []ticket.TicketLabel{"bug", "good-first-issue"},
+				},
+			},
+		},
+		{
+			name:  "url without body",
+			input: `[{"number": 1, "title": "Idea", "state": "open", "url": "https://github.com/o/r/issues/1"}]`,
+			want:  []ticket.Ticket{{Status: ticket.Todo, Title: "Idea", Description: "Imported from https://github.com/o/r/issues/1"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tickets, err := GitHub(strings.NewReader(test.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertTickets(t, tickets, test.want)
+		})
+	}
+}
+
+func TestGitHubInvalid(t *testing.T) {
+	if _, err := GitHub(strings.NewReader(`{"number": 1}`)); err == nil {
+		t.Error("GitHub accepted an object instead of a list of issues")
+	}
+}
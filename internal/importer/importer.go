@@ -0,0 +1,27 @@
+// Package importer converts exports of other task trackers into tickets
+package importer
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/Kavantix/kantui/internal/ticket"
+)
+
+func uniqueLabels(labels []ticket.TicketLabel) []ticket.TicketLabel {
+	var result []ticket.TicketLabel
+	for _, label := range labels {
+		label = ticket.TicketLabel(strings.Join(strings.Fields(string(label)), "-"))
+		if label != "" && !slices.Contains(result, label) {
+			result = append(result, label)
+		}
+	}
+	return result
+}
+
+func taskListItem(done bool, text string) string {
+	if done {
+		return "- [x] " + text
+	}
+	return "- [ ] " + text
+}
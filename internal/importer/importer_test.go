@@ -0,0 +1,37 @@
+package importer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Kavantix/kantui/internal/ticket"
+)
+
+func assertTickets(t *testing.T, got, want []ticket.Ticket) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("imported %d tickets, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("ticket %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestUniqueLabels(t *testing.T) {
+	tests := []struct {
+		labels []ticket.TicketLabel
+		want   []ticket.TicketLabel
+	}{
+		{nil, nil},
+		{[]ticket.TicketLabel{"bug", "", "  "}, []ticket.TicketLabel{"bug"}},
+		{[]ticket.TicketLabel{"needs review", " needs  review "}, []ticket.TicketLabel{"needs-review"}},
+		{[]ticket.TicketLabel{"b", "a", "b"}, []ticket.TicketLabel{"b", "a"}},
+	}
+	for _, test := range tests {
+		if got := uniqueLabels(test.labels); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("uniqueLabels(%q) = %q, want %q", test.labels, got, test.want)
+		}
+	}
+}
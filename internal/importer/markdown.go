@@ -0,0 +1,73 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/Kavantix/kantui/internal/ticket"
+)
+
+var markdownTask = regexp.MustCompile(`^(\s*)[-*+] \[([ xX])\] (.*)$`)
+
+// Markdown reads the task list items (`- [ ] item`) of a markdown file.
+// Open tasks are added to the first column and completed tasks to the last.
+// Lines indented below a task form its description, other lines are ignored.
+func Markdown(r io.Reader) ([]ticket.Ticket, error) {
+	var tickets []ticket.Ticket
+	var description []string
+	inTask := false
+	taskIndent := 0
+	addDescription := func() {
+		if inTask {
+			text := strings.TrimSpace(strings.Join(description, "\n"))
+			tickets[len(tickets)-1].Description = ticket.TicketDescription(text)
+		}
+		description = nil
+		inTask = false
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t")
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if inTask && (line == "" || indent > taskIndent) {
+			description = append(description, dedent(line, taskIndent+2))
+			continue
+		}
+
+		matches := markdownTask.FindStringSubmatch(line)
+		if matches == nil {
+			addDescription()
+			continue
+		}
+		title := strings.TrimSpace(matches[3])
+		if title == "" {
+			continue
+		}
+		addDescription()
+		inTask = true
+		taskIndent = len(matches[1])
+		tickets = append(tickets, ticket.Ticket{
+			Status: completedStatus(matches[2] != " "),
+			Title:  ticket.TicketTitle(title),
+		})
+	}
+	addDescription()
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read markdown: %w", err)
+	}
+	return tickets, nil
+}
+
+func dedent(line string, indent int) string {
+	for range indent {
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			break
+		}
+		line = line[1:]
+	}
+	return line
+}
@@ -0,0 +1,59 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Kavantix/kantui/internal/ticket"
+)
+
+func TestMarkdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []ticket.Ticket
+	}{
+		{"no tasks", "# Notes\n\n- a list item\n", nil},
+		{
+			name:  "open and completed",
+			input: "- [ ] Open\n* [x] Done\n+ [X] Also done\n",
+			want: []ticket.Ticket{
+				{Status: ticket.Todo, Title: "Open"},
+				{Status: ticket.Done, Title: "Done"},
+				{Status: ticket.Done, Title: "Also done"},
+			},
+		},
+		{
+			name:  "description",
+			input: "## Todo\n\n- [ ] Write docs\n  First line\n\n    indented code\n- [ ] Next\nNot part of a task\n",
+			want: []ticket.Ticket{
+				{Status: ticket.Todo, Title: "Write docs", Description: "First line\n\n  indented code"},
+				{Status: ticket.Todo, Title: "Next"},
+			},
+		},
+		{
+			name:  "nested task is part of the description",
+			input: "- [ ] Parent\n  - [x] Child\n",
+			want:  []ticket.Ticket{{Status: ticket.Todo, Title: "Parent", Description: "- [x] Child"}},
+		},
+		{
+			name:  "empty title is skipped",
+			input: "- [ ]  \n- [ ] Real\n",
+			want:  []ticket.Ticket{{Status: ticket.Todo, Title: "Real"}},
+		},
+		{
+			name:  "text after a task ends its description",
+			input: "- [ ] Task\n  details\nParagraph\n  indented\n",
+			want:  []ticket.Ticket{{Status: ticket.Todo, Title: "Task", Description: "details"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tickets, err := Markdown(strings.NewReader(test.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertTickets(t, tickets, test.want)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/Kavantix/kantui/internal/ticket"
+)
+
+var (
+	todoTxtDate     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	todoTxtPriority = regexp.MustCompile(`^\(([A-Z])\)$`)
+	// todoTxtTag matches key:value tags, values starting with a slash are part of a url
+	todoTxtTag = regexp.MustCompile(`^([^:]+):([^:/][^:]*)$`)
+)
+
+// TodoTxt reads a todo.txt file.
+// Open tasks are added to the first column and completed tasks to the last.
+// Projects and contexts become labels, contexts keep their @ prefix.
+// The priority (A) and the pri:A tag of completed tasks become a priority:A label
+// and other key:value tags, like due:2024-05-01, become labels as they are written.
+func TodoTxt(r io.Reader) ([]ticket.Ticket, error) {
+	var tickets []ticket.Ticket
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		completed := fields[0] == "x"
+		if completed {
+			fields = fields[1:]
+		}
+		var labels []ticket.TicketLabel
+		if len(fields) > 0 {
+			if matches := todoTxtPriority.FindStringSubmatch(fields[0]); matches != nil {
+				labels = append(labels, ticket.TicketLabel(ticket.PriorityPrefix+matches[1]))
+				fields = fields[1:]
+			}
+		}
+		// Completion and creation dates are not tracked
+		for len(fields) > 0 && todoTxtDate.MatchString(fields[0]) {
+			fields = fields[1:]
+		}
+
+		var title []string
+		for _, field := range fields {
+			tag := todoTxtTag.FindStringSubmatch(field)
+			switch {
+			case len(field) > 1 && field[0] == '+':
+				labels = append(labels, ticket.TicketLabel(field[1:]))
+			case len(field) > 1 && field[0] == '@':
+				labels = append(labels, ticket.TicketLabel(field))
+			case tag != nil && strings.EqualFold(tag[1], "pri") && todoTxtPriority.MatchString("("+tag[2]+")"):
+				labels = append(labels, ticket.TicketLabel(ticket.PriorityPrefix+tag[2]))
+			case tag != nil:
+				labels = append(labels, ticket.TicketLabel(field))
+			default:
+				title = append(title, field)
+			}
+		}
+		if len(title) == 0 {
+			continue
+		}
+		tickets = append(tickets, ticket.Ticket{
+			Status: completedStatus(completed),
+			Title:  ticket.TicketTitle(strings.Join(title, " ")),
+			Labels: uniqueLabels(labels),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read todo.txt: %w", err)
+	}
+	return tickets, nil
+}
+
+func completedStatus(completed bool) ticket.Status {
+	if completed {
+		return ticket.Statusses[len(ticket.Statusses)-1]
+	}
+	return ticket.Statusses[0]
+}
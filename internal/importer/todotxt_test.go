@@ -0,0 +1,71 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Kavantix/kantui/internal/ticket"
+)
+
+func TestTodoTxt(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []ticket.Ticket
+	}{
+		{"empty lines", "\n  \n", nil},
+		{"plain task", "Call mom\n", []ticket.Ticket{{Status: ticket.Todo, Title: "Call mom"}}},
+		{
+			name:  "projects and contexts",
+			input: "Fix bike +garage @home +garage\n",
+			want:  []ticket.Ticket{{Status: ticket.Todo, Title: "Fix bike", Labels: []ticket.TicketLabel{"garage", "@home"}}},
+		},
+		{
+			name:  "priority and creation date",
+			input: "(A) 2024-05-01 Pay taxes\n",
+			want:  []ticket.Ticket{{Status: ticket.Todo, Title: "Pay taxes", Labels: []ticket.TicketLabel{"priority:A"}}},
+		},
+		{
+			name:  "completed with dates and pri tag",
+			input: "x 2024-05-02 2024-05-01 Pay taxes pri:B\n",
+			want:  []ticket.Ticket{{Status: ticket.Done, Title: "Pay taxes", Labels: []ticket.TicketLabel{"priority:B"}}},
+		},
+		{
+			name:  "tags",
+			input: "Renew passport due:2024-06-01 rec:1y see https://example.com\n",
+			want: []ticket.Ticket{{
+				Status: ticket.Todo,
+				Title:  "Renew passport see https://example.com",
+				Labels: []ticket.TicketLabel{"due:2024-06-01", "rec:1y"},
+			}},
+		},
+		{
+			name:  "lowercase priority is part of the title",
+			input: "(a) thing\n",
+			want:  []ticket.Ticket{{Status: ticket.Todo, Title: "(a) thing"}},
+		},
+		{
+			name:  "priority after the text is part of the title",
+			input: "thing (A)\n",
+			want:  []ticket.Ticket{{Status: ticket.Todo, Title: "thing (A)"}},
+		},
+		{"only labels", "+garage @home\n", nil},
+		{
+			name:  "multiple tasks",
+			input: "x done task\nopen task\n",
+			want: []ticket.Ticket{
+				{Status: ticket.Done, Title: "done task"},
+				{Status: ticket.Todo, Title: "open task"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tickets, err := TodoTxt(strings.NewReader(test.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertTickets(t, tickets, test.want)
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/Kavantix/kantui/internal/ticket"
+)
+
+type trelloBoard struct {
+	Lists      []trelloList      `json:"lists"`
+	Cards      []trelloCard      `json:"cards"`
+	Labels     []trelloLabel     `json:"labels"`
+	Checklists []trelloChecklist `json:"checklists"`
+}
+
+type trelloList struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Closed bool    `json:"closed"`
+	Pos    float64 `json:"pos"`
+}
+
+type trelloCard struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Desc         string   `json:"desc"`
+	Closed       bool     `json:"closed"`
+	Pos          float64  `json:"pos"`
+	IDList       string   `json:"idList"`
+	IDLabels     []string `json:"idLabels"`
+	IDChecklists []string `json:"idChecklists"`
+}
+
+type trelloLabel struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type trelloChecklist struct {
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	Pos        float64           `json:"pos"`
+	CheckItems []trelloCheckItem `json:"checkItems"`
+}
+
+type trelloCheckItem struct {
+	Name  string  `json:"name"`
+	State string  `json:"state"`
+	Pos   float64 `json:"pos"`
+}
+
+// Trello reads a board exported as JSON from trello.
+// Lists are mapped to statusses, cards to tickets and checklists are appended
+// to the description as markdown task lists.
+// Archived lists and cards are skipped.
+func Trello(r io.Reader) ([]ticket.Ticket, error) {
+	var board trelloBoard
+	if err := json.NewDecoder(r).Decode(&board); err != nil {
+		return nil, fmt.Errorf("failed to decode trello board: %w", err)
+	}
+
+	lists := slices.DeleteFunc(board.Lists, func(list trelloList) bool { return list.Closed })
+	slices.SortStableFunc(lists, func(a, b trelloList) int { return compareFloat(a.Pos, b.Pos) })
+	listIndices := map[string]int{}
+	listStatusses := map[string]ticket.Status{}
+	for i, list := range lists {
+		listIndices[list.ID] = i
+		listStatusses[list.ID] = trelloListStatus(list.Name, i, len(lists))
+	}
+
+	labels := map[string]ticket.TicketLabel{}
+	for _, label := range board.Labels {
+		name := strings.TrimSpace(label.Name)
+		if name == "" {
+			name = label.Color
+		}
+		if name != "" {
+			labels[label.ID] = ticket.TicketLabel(name)
+		}
+	}
+
+	checklists := map[string]trelloChecklist{}
+	for _, checklist := range board.Checklists {
+		checklists[checklist.ID] = checklist
+	}
+
+	cards := slices.DeleteFunc(board.Cards, func(card trelloCard) bool {
+		_, ok := listIndices[card.IDList]
+		return card.Closed || !ok
+	})
+	slices.SortStableFunc(cards, func(a, b trelloCard) int {
+		if a.IDList != b.IDList {
+			return listIndices[a.IDList] - listIndices[b.IDList]
+		}
+		return compareFloat(a.Pos, b.Pos)
+	})
+
+	tickets := make([]ticket.Ticket, 0, len(cards))
+	for _, card := range cards {
+		var cardChecklists []trelloChecklist
+		for _, id := range card.IDChecklists {
+			if checklist, ok := checklists[id]; ok {
+				cardChecklists = append(cardChecklists, checklist)
+			}
+		}
+		var cardLabels []ticket.TicketLabel
+		for _, id := range card.IDLabels {
+			if label, ok := labels[id]; ok {
+				cardLabels = append(cardLabels, label)
+			}
+		}
+		tickets = append(tickets, ticket.Ticket{
+			Status:      listStatusses[card.IDList],
+			Title:       ticket.TicketTitle(strings.TrimSpace(card.Name)),
+			Description: ticket.TicketDescription(trelloDescription(card.Desc, cardChecklists)),
+			Labels:      uniqueLabels(cardLabels),
+		})
+	}
+	return tickets, nil
+}
+
+// trelloListStatus maps a list to a status based on its name,
+// falling back to its position on the board
+func trelloListStatus(name string, index, count int) ticket.Status {
+	name = strings.ToLower(name)
+	switch {
+	case strings.Contains(name, "done"), strings.Contains(name, "complete"), strings.Contains(name, "finished"):
+		return ticket.Done
+	case strings.Contains(name, "progress"), strings.Contains(name, "doing"), strings.Contains(name, "review"):
+		return ticket.InProgress
+	case strings.Contains(name, "todo"), strings.Contains(name, "to do"), strings.Contains(name, "backlog"):
+		return ticket.Todo
+	}
+	switch {
+	case index == 0:
+		return ticket.Todo
+	case index == count-1:
+		return ticket.Done
+	default:
+		return ticket.InProgress
+	}
+}
+
+func trelloDescription(desc string, checklists []trelloChecklist) string {
+	description := strings.Builder{}
+	description.WriteString(strings.TrimSpace(desc))
+	slices.SortStableFunc(checklists, func(a, b trelloChecklist) int { return compareFloat(a.Pos, b.Pos) })
+	for _, checklist := range checklists {
+		if description.Len() > 0 {
+			description.WriteString("\n\n")
+		}
+		description.WriteString("## ")
+		description.WriteString(checklist.Name)
+		items := slices.Clone(checklist.CheckItems)
+		slices.SortStableFunc(items, func(a, b trelloCheckItem) int { return compareFloat(a.Pos, b.Pos) })
+		for _, item := range items {
+			description.WriteString("\n")
+			description.WriteString(taskListItem(item.State == "complete", item.Name))
+		}
+	}
+	return description.String()
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
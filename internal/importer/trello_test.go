@@ -0,0 +1,83 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Kavantix/kantui/internal/ticket"
+)
+
+const trelloBoardJson = `{
+	"lists": [
+		{"id": "l3", "name": "Shipped", "pos": 3},
+		{"id": "l1", "name": "Ideas", "pos": 1},
+		{"id": "l2", "name": "Doing", "pos": 2},
+		{"id": "l4", "name": "Old", "pos": 0, "closed": true}
+	],
+	"labels": [
+		{"id": "bug", "name": "Bug", "color": "red"},
+		{"id": "green", "name": "", "color": "green"}
+	],
+	"checklists": [
+		{"id": "c2", "name": "Later", "pos": 2, "checkItems": [{"name": "Polish", "state": "incomplete", "pos": 1}]},
+		{"id": "c1", "name": "Steps", "pos": 1, "checkItems": [
+			{"name": "Second", "state": "incomplete", "pos": 2},
+			{"name": "First", "state": "complete", "pos": 1}
+		]}
+	],
+	"cards": [
+		{"id": "a", "name": "Release", "idList": "l3", "pos": 1},
+		{"id": "b", "name": "Login", "desc": "  Use oauth ", "idList": "l2", "pos": 1, "idLabels": ["bug", "green", "missing"], "idChecklists": ["c2", "c1"]},
+		{"id": "c", "name": "Second idea", "idList": "l1", "pos": 2},
+		{"id": "d", "name": "First idea", "idList": "l1", "pos": 1},
+		{"id": "e", "name": "Archived", "idList": "l1", "pos": 3, "closed": true},
+		{"id": "f", "name": "In closed list", "idList": "l4", "pos": 1}
+	]
+}`
+
+func TestTrello(t *testing.T) {
+	tickets, err := Trello(strings.NewReader(trelloBoardJson))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertTickets(t, tickets, []ticket.Ticket{
+		{Status: ticket.Todo, Title: "First idea"},
+		{Status: ticket.Todo, Title: "Second idea"},
+		{
+			Status:      ticket.InProgress,
+			Title:       "Login",
+			Description: "Use oauth\n\n## Steps\n- [x] First\n- [ ] Second\n\n## Later\n- [ ] Polish",
+			Labels:      []ticket.TicketLabel{"Bug", "green"},
+		},
+		{Status: ticket.Done, Title: "Release"},
+	})
+}
+
+func TestTrelloListStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		index, count int
+		want         ticket.Status
+	}{
+		{"Backlog", 2, 3, ticket.Todo},
+		{"To Do", 1, 3, ticket.Todo},
+		{"In Progress", 0, 3, ticket.InProgress},
+		{"Code review", 0, 3, ticket.InProgress},
+		{"Completed", 0, 3, ticket.Done},
+		{"Ideas", 0, 4, ticket.Todo},
+		{"Week 1", 1, 4, ticket.InProgress},
+		{"Week 2", 2, 4, ticket.InProgress},
+		{"Archive", 3, 4, ticket.Done},
+	}
+	for _, test := range tests {
+		if got := trelloListStatus(test.name, test.index, test.count); got != test.want {
+			t.Errorf("trelloListStatus(%q, %d, %d) = %s, want %s", test.name, test.index, test.count, got, test.want)
+		}
+	}
+}
+
+func TestTrelloInvalid(t *testing.T) {
+	if _, err := Trello(strings.NewReader(`[]`)); err == nil {
+		t.Error("Trello accepted a list instead of a board")
+	}
+}
@@ -0,0 +1,341 @@
+// Package keys defines the keybindings of all models,
+// so the help and key hints always match the handlers
+package keys
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+)
+
+type KeyMap struct {
+	Board   BoardKeyMap
+	Column  ColumnKeyMap
+	Ticket  TicketKeyMap
+	Confirm ConfirmKeyMap
+	Move    MoveKeyMap
+	Label   LabelKeyMap
+	Search  SearchKeyMap
+	Query   QueryKeyMap
+	Views   ViewsKeyMap
+	Table   TableKeyMap
+	Help    HelpKeyMap
+}
+
+// BoardKeyMap is handled by the app when no modal is shown
+type BoardKeyMap struct {
+	Quit         key.Binding
+	ForceQuit    key.Binding
+	Help         key.Binding
+	Search       key.Binding
+	Query        key.Binding
+	Views        key.Binding
+	SaveView     key.Binding
+	SwitchView   key.Binding
+	ResetView    key.Binding
+	Collapse     key.Binding
+	Hide         key.Binding
+	ShowHidden   key.Binding
+	Swimlanes    key.Binding
+	CollapseLane key.Binding
+	PreviousLane key.Binding
+	NextLane     key.Binding
+	Table        key.Binding
+	Details      key.Binding
+	// MoveDetails switches the details between the right of the board and below it
+	MoveDetails  key.Binding
+	DetailsUp    key.Binding
+	DetailsDown  key.Binding
+	FocusLeft    key.Binding
+	FocusRight   key.Binding
+	RetryError   key.Binding
+	DismissError key.Binding
+}
+
+// ColumnKeyMap is handled by the focused column
+type ColumnKeyMap struct {
+	Up             key.Binding
+	Down           key.Binding
+	PrevPage       key.Binding
+	NextPage       key.Binding
+	GoToStart      key.Binding
+	GoToEnd        key.Binding
+	Filter         key.Binding
+	ClearFilter    key.Binding
+	AcceptFilter   key.Binding
+	CancelFilter   key.Binding
+	Create         key.Binding
+	Edit           key.Binding
+	Delete         key.Binding
+	PreviousStatus key.Binding
+	NextStatus     key.Binding
+	Move           key.Binding
+	Label          key.Binding
+	Archive        key.Binding
+	Sort           key.Binding
+	ToggleSelect   key.Binding
+	SelectAll      key.Binding
+	RankUp         key.Binding
+	RankDown       key.Binding
+	RankTop        key.Binding
+	RankBottom     key.Binding
+}
+
+// TicketKeyMap is handled by the ticket editor modal
+type TicketKeyMap struct {
+	Save          key.Binding
+	Close         key.Binding
+	NextField     key.Binding
+	PreviousField key.Binding
+	Quit          key.Binding
+}
+
+// ConfirmKeyMap is handled by the confirm modal
+type ConfirmKeyMap struct {
+	Confirm key.Binding
+	Cancel  key.Binding
+}
+
+// MoveKeyMap is handled by the move to modal
+type MoveKeyMap struct {
+	Up             key.Binding
+	Down           key.Binding
+	PreviousColumn key.Binding
+	NextColumn     key.Binding
+	Confirm        key.Binding
+	Close          key.Binding
+}
+
+// LabelKeyMap is handled by the label modal
+type LabelKeyMap struct {
+	Save  key.Binding
+	Close key.Binding
+}
+
+// SearchKeyMap is handled by the search modal,
+// letters are typed in the query so they can not be used
+type SearchKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Select key.Binding
+	Close  key.Binding
+}
+
+// QueryKeyMap is handled by the modal that edits the query of the board
+type QueryKeyMap struct {
+	Apply key.Binding
+	Close key.Binding
+}
+
+// ViewsKeyMap is handled by the modals that pick and save views,
+// Save and Cancel apply while typing the name of a view
+type ViewsKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Apply  key.Binding
+	Delete key.Binding
+	Close  key.Binding
+	Save   key.Binding
+	Cancel key.Binding
+}
+
+// TableKeyMap is handled by the table of all tickets, on top of the column keybindings
+type TableKeyMap struct {
+	ReverseSort key.Binding
+}
+
+// HelpKeyMap is handled by the help modal
+type HelpKeyMap struct {
+	Close key.Binding
+}
+
+func Default() KeyMap {
+	return KeyMap{
+		Board: BoardKeyMap{
+			Quit:         key.NewBinding(key.WithKeys("q"), key.WithHelp("q", "quit")),
+			ForceQuit:    key.NewBinding(key.WithKeys("ctrl+c"), key.WithHelp("ctrl+c", "quit, even while filtering")),
+			Help:         key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "help")),
+			Search:       key.NewBinding(key.WithKeys("ctrl+p"), key.WithHelp("ctrl+p", "search all tickets")),
+			Query:        key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "filter board by query")),
+			Views:        key.NewBinding(key.WithKeys("V"), key.WithHelp("V", "saved views")),
+			SaveView:     key.NewBinding(key.WithKeys("S"), key.WithHelp("S", "save view")),
+			SwitchView:   key.NewBinding(key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"), key.WithHelp("1-9", "switch to saved view")),
+			ResetView:    key.NewBinding(key.WithKeys("0"), key.WithHelp("0", "show all tickets and columns")),
+			Collapse:     key.NewBinding(key.WithKeys("z"), key.WithHelp("z", "collapse or expand column")),
+			Hide:         key.NewBinding(key.WithKeys("H"), key.WithHelp("H", "hide column")),
+			ShowHidden:   key.NewBinding(key.WithKeys("U"), key.WithHelp("U", "show hidden columns")),
+			Swimlanes:    key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "change swimlane grouping")),
+			CollapseLane: key.NewBinding(key.WithKeys("Z"), key.WithHelp("Z", "collapse or expand swimlane")),
+			PreviousLane: key.NewBinding(key.WithKeys("[", "ctrl+up"), key.WithHelp("[", "focus swimlane above")),
+			NextLane:     key.NewBinding(key.WithKeys("]", "ctrl+down"), key.WithHelp("]", "focus swimlane below")),
+			Table:        key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "switch between board and table")),
+			Details:      key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "show or hide ticket details")),
+			MoveDetails:  key.NewBinding(key.WithKeys("P"), key.WithHelp("P", "move ticket details right or below")),
+			DetailsUp:    key.NewBinding(key.WithKeys("ctrl+u"), key.WithHelp("ctrl+u", "scroll ticket details up")),
+			DetailsDown:  key.NewBinding(key.WithKeys("ctrl+d"), key.WithHelp("ctrl+d", "scroll ticket details down")),
+			FocusLeft:    key.NewBinding(key.WithKeys("left", "h"), key.WithHelp("←/h", "focus left column")),
+			FocusRight:   key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("→/l", "focus right column")),
+			RetryError:   key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "retry failed change")),
+			DismissError: key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "dismiss error")),
+		},
+		Column: ColumnKeyMap{
+			Up:             key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up")),
+			Down:           key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down")),
+			PrevPage:       key.NewBinding(key.WithKeys("pgup"), key.WithHelp("pgup", "previous page")),
+			NextPage:       key.NewBinding(key.WithKeys("pgdown"), key.WithHelp("pgdown", "next page")),
+			GoToStart:      key.NewBinding(key.WithKeys("home", "g"), key.WithHelp("g/home", "go to start")),
+			GoToEnd:        key.NewBinding(key.WithKeys("end", "G"), key.WithHelp("G/end", "go to end")),
+			Filter:         key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "filter")),
+			ClearFilter:    key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "clear selection or filter")),
+			AcceptFilter:   key.NewBinding(key.WithKeys("enter", "tab", "shift+tab", "ctrl+k", "up", "ctrl+j", "down"), key.WithHelp("enter", "apply filter")),
+			CancelFilter:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel filter")),
+			Create:         key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "create ticket")),
+			Edit:           key.NewBinding(key.WithKeys("e", " "), key.WithHelp("e/space", "edit ticket")),
+			Delete:         key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete ticket")),
+			PreviousStatus: key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "move to previous column")),
+			NextStatus:     key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "move to next column")),
+			Move:           key.NewBinding(key.WithKeys("m"), key.WithHelp("m", "move to…")),
+			Label:          key.NewBinding(key.WithKeys("#"), key.WithHelp("#", "add or remove label")),
+			Archive:        key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "archive")),
+			Sort:           key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "change sort order")),
+			ToggleSelect:   key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "select ticket")),
+			SelectAll:      key.NewBinding(key.WithKeys("ctrl+a"), key.WithHelp("ctrl+a", "select all visible tickets")),
+			RankUp:         key.NewBinding(key.WithKeys("K", "shift+up"), key.WithHelp("K", "rank up")),
+			RankDown:       key.NewBinding(key.WithKeys("J", "shift+down"), key.WithHelp("J", "rank down")),
+			RankTop:        key.NewBinding(key.WithKeys("T"), key.WithHelp("T", "rank to top")),
+			RankBottom:     key.NewBinding(key.WithKeys("B"), key.WithHelp("B", "rank to bottom")),
+		},
+		Ticket: TicketKeyMap{
+			Save:          key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "save")),
+			Close:         key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "close")),
+			NextField:     key.NewBinding(key.WithKeys("enter", "tab"), key.WithHelp("tab", "next field")),
+			PreviousField: key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "previous field")),
+			Quit:          key.NewBinding(key.WithKeys("ctrl+c"), key.WithHelp("ctrl+c", "quit")),
+		},
+		Confirm: ConfirmKeyMap{
+			Confirm: key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "confirm")),
+			Cancel:  key.NewBinding(key.WithKeys("n", "esc"), key.WithHelp("n/esc", "cancel")),
+		},
+		Move: MoveKeyMap{
+			Up:             key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "previous position")),
+			Down:           key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "next position")),
+			PreviousColumn: key.NewBinding(key.WithKeys("left", "h", "shift+tab"), key.WithHelp("←/h", "previous column")),
+			NextColumn:     key.NewBinding(key.WithKeys("right", "l", "tab"), key.WithHelp("→/l", "next column")),
+			Confirm:        key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "move")),
+			Close:          key.NewBinding(key.WithKeys("esc", "q"), key.WithHelp("esc", "cancel")),
+		},
+		Label: LabelKeyMap{
+			Save:  key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "apply label")),
+			Close: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+		},
+		Search: SearchKeyMap{
+			Up:     key.NewBinding(key.WithKeys("up", "ctrl+k", "shift+tab"), key.WithHelp("↑/ctrl+k", "previous match")),
+			Down:   key.NewBinding(key.WithKeys("down", "ctrl+j", "tab"), key.WithHelp("↓/ctrl+j", "next match")),
+			Select: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "go to ticket")),
+			Close:  key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "close")),
+		},
+		Query: QueryKeyMap{
+			Apply: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "apply query")),
+			Close: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+		},
+		Views: ViewsKeyMap{
+			Up:     key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "previous view")),
+			Down:   key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "next view")),
+			Apply:  key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "switch to view")),
+			Delete: key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete view")),
+			Close:  key.NewBinding(key.WithKeys("esc", "q"), key.WithHelp("esc", "close")),
+			Save:   key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "save view")),
+			Cancel: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+		},
+		Table: TableKeyMap{
+			ReverseSort: key.NewBinding(key.WithKeys("O"), key.WithHelp("O", "reverse sort order")),
+		},
+		Help: HelpKeyMap{
+			Close: key.NewBinding(key.WithKeys("esc", "q", "?"), key.WithHelp("esc", "close help")),
+		},
+	}
+}
+
+var active = Default()
+
+// Get returns the keybindings used by all models
+func Get() KeyMap {
+	return active
+}
+
+// Set replaces the keybindings used by all models,
+// it should be called before the program starts
+func Set(keyMap KeyMap) {
+	active = keyMap
+}
+
+// ListKeyMap configures a list to only handle the column keybindings
+func (k ColumnKeyMap) ListKeyMap() list.KeyMap {
+	disabled := key.NewBinding(key.WithDisabled())
+	return list.KeyMap{
+		CursorUp:             k.Up,
+		CursorDown:           k.Down,
+		PrevPage:             k.PrevPage,
+		NextPage:             k.NextPage,
+		GoToStart:            k.GoToStart,
+		GoToEnd:              k.GoToEnd,
+		Filter:               k.Filter,
+		ClearFilter:          disabled,
+		CancelWhileFiltering: k.CancelFilter,
+		AcceptWhileFiltering: k.AcceptFilter,
+		ShowFullHelp:         disabled,
+		CloseFullHelp:        disabled,
+		Quit:                 disabled,
+		ForceQuit:            disabled,
+	}
+}
+
+// Group is a titled set of keybindings shown in the help
+type Group struct {
+	Title    string
+	Bindings []key.Binding
+}
+
+// Groups returns all keybindings grouped by where they are handled
+func (k KeyMap) Groups() []Group {
+	return []Group{
+		{"Board", []key.Binding{
+			k.Board.FocusLeft, k.Board.FocusRight, k.Board.RetryError, k.Board.DismissError,
+			k.Board.Search, k.Board.Query, k.Board.Views, k.Board.SaveView, k.Board.SwitchView,
+			k.Board.ResetView, k.Board.Collapse, k.Board.Hide, k.Board.ShowHidden,
+			k.Board.Swimlanes, k.Board.CollapseLane, k.Board.PreviousLane, k.Board.NextLane, k.Board.Table,
+			k.Board.Details, k.Board.MoveDetails, k.Board.DetailsUp, k.Board.DetailsDown, k.Board.Help, k.Board.Quit, k.Board.ForceQuit,
+		}},
+		{"Column", []key.Binding{
+			k.Column.Up, k.Column.Down, k.Column.PrevPage, k.Column.NextPage,
+			k.Column.GoToStart, k.Column.GoToEnd, k.Column.Filter, k.Column.ClearFilter,
+			k.Column.Create, k.Column.Edit, k.Column.Delete,
+			k.Column.PreviousStatus, k.Column.NextStatus, k.Column.Move,
+			k.Column.Label, k.Column.Archive, k.Column.Sort, k.Column.ToggleSelect, k.Column.SelectAll,
+			k.Column.RankUp, k.Column.RankDown, k.Column.RankTop, k.Column.RankBottom,
+		}},
+		{"Table", []key.Binding{
+			k.Column.Sort, k.Table.ReverseSort,
+		}},
+		{"Ticket editor", []key.Binding{
+			k.Ticket.Save, k.Ticket.NextField, k.Ticket.PreviousField, k.Ticket.Close, k.Ticket.Quit,
+		}},
+		{"Confirm", []key.Binding{
+			k.Confirm.Confirm, k.Confirm.Cancel,
+		}},
+		{"Move to", []key.Binding{
+			k.Move.Up, k.Move.Down, k.Move.PreviousColumn, k.Move.NextColumn, k.Move.Confirm, k.Move.Close,
+		}},
+		{"Label", []key.Binding{
+			k.Label.Save, k.Label.Close,
+		}},
+		{"Search", []key.Binding{
+			k.Search.Up, k.Search.Down, k.Search.Select, k.Search.Close,
+		}},
+		{"Filter board", []key.Binding{
+			k.Query.Apply, k.Query.Close,
+		}},
+		{"Views", []key.Binding{
+			k.Views.Up, k.Views.Down, k.Views.Apply, k.Views.Delete, k.Views.Close, k.Views.Save, k.Views.Cancel,
+		}},
+	}
+}
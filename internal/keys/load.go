@@ -0,0 +1,384 @@
+package keys
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"gopkg.in/yaml.v3"
+)
+
+// named returns the bindings by the group and action names used in the keymap file
+func (k *KeyMap) named() map[string]map[string]*key.Binding {
+	return map[string]map[string]*key.Binding{
+		"board": {
+			"quit":          &k.Board.Quit,
+			"force_quit":    &k.Board.ForceQuit,
+			"help":          &k.Board.Help,
+			"search":        &k.Board.Search,
+			"query":         &k.Board.Query,
+			"views":         &k.Board.Views,
+			"save_view":     &k.Board.SaveView,
+			"switch_view":   &k.Board.SwitchView,
+			"reset_view":    &k.Board.ResetView,
+			"collapse":      &k.Board.Collapse,
+			"hide":          &k.Board.Hide,
+			"show_hidden":   &k.Board.ShowHidden,
+			"swimlanes":     &k.Board.Swimlanes,
+			"collapse_lane": &k.Board.CollapseLane,
+			"previous_lane": &k.Board.PreviousLane,
+			"next_lane":     &k.Board.NextLane,
+			"table":         &k.Board.Table,
+			"details":       &k.Board.Details,
+			"move_details":  &k.Board.MoveDetails,
+			"details_up":    &k.Board.DetailsUp,
+			"details_down":  &k.Board.DetailsDown,
+			"focus_left":    &k.Board.FocusLeft,
+			"focus_right":   &k.Board.FocusRight,
+			"retry_error":   &k.Board.RetryError,
+			"dismiss_error": &k.Board.DismissError,
+		},
+		"column": {
+			"up":              &k.Column.Up,
+			"down":            &k.Column.Down,
+			"prev_page":       &k.Column.PrevPage,
+			"next_page":       &k.Column.NextPage,
+			"go_to_start":     &k.Column.GoToStart,
+			"go_to_end":       &k.Column.GoToEnd,
+			"filter":          &k.Column.Filter,
+			"clear_filter":    &k.Column.ClearFilter,
+			"accept_filter":   &k.Column.AcceptFilter,
+			"cancel_filter":   &k.Column.CancelFilter,
+			"create":          &k.Column.Create,
+			"edit":            &k.Column.Edit,
+			"delete":          &k.Column.Delete,
+			"previous_status": &k.Column.PreviousStatus,
+			"next_status":     &k.Column.NextStatus,
+			"move":            &k.Column.Move,
+			"label":           &k.Column.Label,
+			"archive":         &k.Column.Archive,
+			"sort":            &k.Column.Sort,
+			"toggle_select":   &k.Column.ToggleSelect,
+			"select_all":      &k.Column.SelectAll,
+			"rank_up":         &k.Column.RankUp,
+			"rank_down":       &k.Column.RankDown,
+			"rank_top":        &k.Column.RankTop,
+			"rank_bottom":     &k.Column.RankBottom,
+		},
+		"ticket": {
+			"save":           &k.Ticket.Save,
+			"close":          &k.Ticket.Close,
+			"next_field":     &k.Ticket.NextField,
+			"previous_field": &k.Ticket.PreviousField,
+			"quit":           &k.Ticket.Quit,
+		},
+		"confirm": {
+			"confirm": &k.Confirm.Confirm,
+			"cancel":  &k.Confirm.Cancel,
+		},
+		"move": {
+			"up":              &k.Move.Up,
+			"down":            &k.Move.Down,
+			"previous_column": &k.Move.PreviousColumn,
+			"next_column":     &k.Move.NextColumn,
+			"confirm":         &k.Move.Confirm,
+			"close":           &k.Move.Close,
+		},
+		"label": {
+			"save":  &k.Label.Save,
+			"close": &k.Label.Close,
+		},
+		"search": {
+			"up":     &k.Search.Up,
+			"down":   &k.Search.Down,
+			"select": &k.Search.Select,
+			"close":  &k.Search.Close,
+		},
+		"query": {
+			"apply": &k.Query.Apply,
+			"close": &k.Query.Close,
+		},
+		"views": {
+			"up":     &k.Views.Up,
+			"down":   &k.Views.Down,
+			"apply":  &k.Views.Apply,
+			"delete": &k.Views.Delete,
+			"close":  &k.Views.Close,
+			"save":   &k.Views.Save,
+			"cancel": &k.Views.Cancel,
+		},
+		"table": {
+			"reverse_sort": &k.Table.ReverseSort,
+		},
+		"help": {
+			"close": &k.Help.Close,
+		},
+	}
+}
+
+// Scopes that support key sequences, passed to SequenceState
+const (
+	BoardScope = "board"
+	TableScope = "table"
+)
+
+// scope is a set of bindings that are handled at the same time,
+// so they can not share keys
+type scope struct {
+	name              string
+	bindings          []string
+	supportsSequences bool
+}
+
+var scopes = []scope{
+	{
+		name: BoardScope,
+		bindings: []string{
+			"board.quit", "board.force_quit", "board.help", "board.search", "board.query",
+			"board.views", "board.save_view", "board.switch_view", "board.reset_view",
+			"board.collapse", "board.hide", "board.show_hidden",
+			"board.swimlanes", "board.collapse_lane", "board.previous_lane", "board.next_lane", "board.table",
+			"board.details", "board.move_details", "board.details_up", "board.details_down",
+			"board.focus_left", "board.focus_right",
+			"board.retry_error", "board.dismiss_error",
+			"column.up", "column.down", "column.prev_page", "column.next_page",
+			"column.go_to_start", "column.go_to_end", "column.filter", "column.clear_filter",
+			"column.create", "column.edit", "column.delete",
+			"column.previous_status", "column.next_status", "column.move",
+			"column.label", "column.archive", "column.sort", "column.toggle_select", "column.select_all",
+			"column.rank_up", "column.rank_down", "column.rank_top", "column.rank_bottom",
+		},
+		supportsSequences: true,
+	},
+	{
+		name:     "filter",
+		bindings: []string{"board.force_quit", "column.accept_filter", "column.cancel_filter"},
+	},
+	{
+		name:     "ticket editor",
+		bindings: []string{"ticket.save", "ticket.close", "ticket.next_field", "ticket.previous_field", "ticket.quit"},
+	},
+	{
+		name: TableScope,
+		bindings: []string{
+			"board.quit", "board.force_quit", "board.help", "board.search", "board.query",
+			"board.views", "board.save_view", "board.switch_view", "board.reset_view", "board.table",
+			"board.details", "board.move_details", "board.details_up", "board.details_down",
+			"board.retry_error", "board.dismiss_error",
+			"column.up", "column.down", "column.prev_page", "column.next_page",
+			"column.go_to_start", "column.go_to_end", "column.filter", "column.clear_filter",
+			"column.create", "column.edit", "column.delete",
+			"column.previous_status", "column.next_status", "column.move",
+			"column.label", "column.archive", "column.sort", "table.reverse_sort",
+		},
+		supportsSequences: true,
+	},
+	{
+		name:     "confirm",
+		bindings: []string{"confirm.confirm", "confirm.cancel"},
+	},
+	{
+		name:     "move to",
+		bindings: []string{"move.up", "move.down", "move.previous_column", "move.next_column", "move.confirm", "move.close"},
+	},
+	{
+		name:     "label editor",
+		bindings: []string{"label.save", "label.close"},
+	},
+	{
+		name:     "search",
+		bindings: []string{"search.up", "search.down", "search.select", "search.close"},
+	},
+	{
+		name:     "query",
+		bindings: []string{"query.apply", "query.close"},
+	},
+	{
+		name:     "views",
+		bindings: []string{"views.up", "views.down", "views.apply", "views.delete", "views.close"},
+	},
+	{
+		name:     "view name",
+		bindings: []string{"views.save", "views.cancel"},
+	},
+	{
+		name:     "help",
+		bindings: []string{"help.close"},
+	},
+}
+
+// keyList accepts both a single key and a list of keys
+type keyList []string
+
+func (l *keyList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*l = keyList{value.Value}
+		return nil
+	}
+	var keys []string
+	if err := value.Decode(&keys); err != nil {
+		return err
+	}
+	*l = keys
+	return nil
+}
+
+// Load reads the keymap file on top of the default keybindings.
+// The file maps the group and action names to one or more keys,
+// a sequence of keys is separated by spaces, e.g. `rank_top: "g g"`.
+// When required is false a missing file results in the default keybindings.
+func Load(file string, required bool) (KeyMap, error) {
+	keyMap := Default()
+	content, err := os.ReadFile(file)
+	if errors.Is(err, fs.ErrNotExist) && !required {
+		return keyMap, keyMap.Validate()
+	}
+	if err != nil {
+		return keyMap, fmt.Errorf("failed to read keymap: %w", err)
+	}
+
+	var config map[string]map[string]keyList
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return keyMap, fmt.Errorf("failed to parse keymap %s: %w", file, err)
+	}
+	if err := keyMap.apply(config); err != nil {
+		return keyMap, fmt.Errorf("invalid keymap %s: %w", file, err)
+	}
+	if err := keyMap.Validate(); err != nil {
+		return keyMap, fmt.Errorf("invalid keymap %s: %w", file, err)
+	}
+	return keyMap, nil
+}
+
+func (k *KeyMap) apply(config map[string]map[string]keyList) error {
+	named := k.named()
+	for group, actions := range config {
+		bindings, ok := named[group]
+		if !ok {
+			return fmt.Errorf("unknown group %q", group)
+		}
+		for action, keys := range actions {
+			binding, ok := bindings[action]
+			if !ok {
+				return fmt.Errorf("unknown action %q in group %q", action, group)
+			}
+			*binding = newBinding(keys, binding.Help().Desc)
+		}
+	}
+	return nil
+}
+
+func newBinding(keys []string, description string) key.Binding {
+	if len(keys) == 0 {
+		return key.NewBinding(key.WithDisabled())
+	}
+	var normalized, help []string
+	for _, k := range keys {
+		// Space is sent as " " on its own but named inside a sequence
+		sequence := strings.Join(strings.Fields(k), " ")
+		help = append(help, sequence)
+		if sequence == "space" {
+			sequence = " "
+		}
+		normalized = append(normalized, sequence)
+	}
+	return key.NewBinding(
+		key.WithKeys(normalized...),
+		key.WithHelp(strings.Join(help, "/"), description),
+	)
+}
+
+// isSequence reports whether the key consists of multiple key presses,
+// the space key itself is not a sequence
+func isSequence(k string) bool {
+	return strings.Contains(strings.TrimSpace(k), " ")
+}
+
+// Validate checks that no key is bound to multiple actions that are handled
+// at the same time and that sequences do not start with a bound key
+func (k KeyMap) Validate() error {
+	named := k.named()
+	var problems []string
+	for _, scope := range scopes {
+		actions := map[string]string{}
+		var sequences []string
+		for _, name := range scope.bindings {
+			group, action, _ := strings.Cut(name, ".")
+			binding := named[group][action]
+			if !binding.Enabled() {
+				continue
+			}
+			for _, k := range binding.Keys() {
+				if other, ok := actions[k]; ok && other != name {
+					problems = append(problems, fmt.Sprintf("%q is bound to both %s and %s", k, other, name))
+				}
+				actions[k] = name
+				if isSequence(k) {
+					if !scope.supportsSequences {
+						problems = append(problems, fmt.Sprintf("%s uses the sequence %q but sequences are not supported in the %s", name, k, scope.name))
+					}
+					sequences = append(sequences, k)
+				}
+			}
+		}
+		for _, sequence := range sequences {
+			keys := strings.Split(sequence, " ")
+			for i := 1; i < len(keys); i++ {
+				prefix := strings.Join(keys[:i], " ")
+				bound := prefix
+				if bound == "space" {
+					bound = " "
+				}
+				if other, ok := actions[bound]; ok {
+					problems = append(problems, fmt.Sprintf("sequence %q of %s starts with %q which is bound to %s", sequence, actions[sequence], prefix, other))
+				}
+			}
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return errors.New(strings.Join(slices.Compact(problems), "\n"))
+	}
+	return nil
+}
+
+// SequenceState reports whether the keys typed so far complete a sequence
+// of the keybindings of the scope or are the start of one
+func (k KeyMap) SequenceState(scopeName string, keys []string) (complete string, isPrefix bool) {
+	index := slices.IndexFunc(scopes, func(scope scope) bool { return scope.name == scopeName })
+	if index < 0 || !scopes[index].supportsSequences {
+		return "", false
+	}
+	named := make([]string, len(keys))
+	for i, k := range keys {
+		if k == " " {
+			k = "space"
+		}
+		named[i] = k
+	}
+	typed := strings.Join(named, " ")
+	bindings := k.named()
+	for _, name := range scopes[index].bindings {
+		group, action, _ := strings.Cut(name, ".")
+		binding := bindings[group][action]
+		if !binding.Enabled() {
+			continue
+		}
+		for _, k := range binding.Keys() {
+			if !isSequence(k) {
+				continue
+			}
+			if k == typed {
+				complete = k
+			} else if strings.HasPrefix(k, typed+" ") {
+				isPrefix = true
+			}
+		}
+	}
+	return complete, isPrefix
+}
@@ -0,0 +1,110 @@
+package keys
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func loadKeymap(t *testing.T, content string) (KeyMap, error) {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "keymap.yaml")
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return Load(file, true)
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		keymap  string
+		wantErr string
+	}{
+		{"empty", "", ""},
+		{"single key", "board:\n  search: ctrl+f\n", ""},
+		{"list of keys", "column:\n  rank_top: [T, \"ctrl+w t\"]\n", ""},
+		{"disabled binding frees its key", "column:\n  delete: []\nboard:\n  search: d\n", ""},
+		{"same key in other scopes", "ticket:\n  save: q\n", ""},
+		{"unknown group", "boards:\n  quit: q\n", `unknown group "boards"`},
+		{"unknown action", "board:\n  exit: q\n", `unknown action "exit" in group "board"`},
+		{"invalid yaml", "board: [", "failed to parse keymap"},
+		{"conflict", "board:\n  search: q\n", `"q" is bound to both`},
+		{"conflict in the table", "table:\n  reverse_sort: o\n", `"o" is bound to both column.sort and table.reverse_sort`},
+		{"sequence in unsupported scope", "ticket:\n  save: \"ctrl+x s\"\n", "sequences are not supported in the ticket editor"},
+		{"sequence starts with bound key", "column:\n  rank_top: \"g g\"\n", `sequence "g g" of column.rank_top starts with "g" which is bound to column.go_to_start`},
+		{"sequence starts with space", "column:\n  rank_top: \"space t\"\n", `starts with "space" which is bound to column.edit`},
+		{"sequence after freeing the prefix", "column:\n  go_to_start: home\n  rank_top: \"g g\"\n", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := loadKeymap(t, test.keymap)
+			if test.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Load failed: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("Load returned error %v, want %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "keymap.yaml")
+	if _, err := Load(file, false); err != nil {
+		t.Errorf("Load of a missing optional keymap failed: %v", err)
+	}
+	if _, err := Load(file, true); err == nil {
+		t.Error("Load of a missing required keymap succeeded")
+	}
+}
+
+func TestDefaultIsValid(t *testing.T) {
+	if err := Default().Validate(); err != nil {
+		t.Errorf("default keymap is invalid: %v", err)
+	}
+}
+
+func TestSequenceState(t *testing.T) {
+	keyMap, err := loadKeymap(t, `
+column:
+  edit: e
+  rank_top: "ctrl+w t"
+  rank_bottom: "space b"
+table:
+  reverse_sort: "ctrl+w r"
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		scope    string
+		keys     []string
+		complete string
+		isPrefix bool
+	}{
+		{BoardScope, []string{"ctrl+w"}, "", true},
+		{BoardScope, []string{"ctrl+w", "t"}, "ctrl+w t", false},
+		{BoardScope, []string{"ctrl+w", "r"}, "", false},
+		{BoardScope, []string{" "}, "", true},
+		{BoardScope, []string{" ", "b"}, "space b", false},
+		{BoardScope, []string{"t"}, "", false},
+		{TableScope, []string{"ctrl+w"}, "", true},
+		{TableScope, []string{"ctrl+w", "r"}, "ctrl+w r", false},
+		{TableScope, []string{"ctrl+w", "t"}, "", false},
+		{TableScope, []string{" "}, "", false},
+		{"filter", []string{"ctrl+w"}, "", false},
+		{"unknown", []string{"ctrl+w"}, "", false},
+	}
+	for _, test := range tests {
+		complete, isPrefix := keyMap.SequenceState(test.scope, test.keys)
+		if complete != test.complete || isPrefix != test.isPrefix {
+			t.Errorf("SequenceState(%q, %q) = %q, %t, want %q, %t", test.scope, test.keys, complete, isPrefix, test.complete, test.isPrefix)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Package label asks for a label to add to or remove from tickets
+package label
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Kavantix/kantui/internal/keys"
+	"github.com/Kavantix/kantui/internal/messages"
+	"github.com/Kavantix/kantui/internal/overlay"
+	"github.com/Kavantix/kantui/internal/statusbar"
+	"github.com/Kavantix/kantui/internal/theme"
+	"github.com/Kavantix/kantui/internal/ticket"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type Model struct {
+	ids   []ticket.TicketId
+	store ticket.Store
+	input textinput.Model
+}
+
+// assert
+var _ overlay.ModalModel = Model{}
+var _ statusbar.Hinter = Model{}
+
+func Show(ids []ticket.TicketId, store ticket.Store) tea.Cmd {
+	return func() tea.Msg {
+		input := textinput.New()
+		input.Placeholder = "label, or -label to remove it"
+		input.Prompt = "#"
+		input.Width = 40
+		input.Focus()
+		return Model{
+			ids:   ids,
+			store: store,
+			input: input,
+		}
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		keyMap := keys.Get().Label
+		switch {
+		case key.Matches(msg, keyMap.Save):
+			return m, tea.Batch(m.apply(), messages.CloseModal)
+		case key.Matches(msg, keyMap.Close):
+			return m, messages.CloseModal
+		}
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// apply adds the label, or removes it when it starts with a -,
+// whitespace is replaced by dashes like the importers do
+func (m Model) apply() tea.Cmd {
+	value := strings.TrimPrefix(strings.TrimSpace(m.input.Value()), "#")
+	remove := strings.HasPrefix(value, "-")
+	label := ticket.TicketLabel(strings.Join(strings.Fields(strings.TrimPrefix(value, "-")), "-"))
+	if label == "" {
+		return nil
+	}
+	if remove {
+		return m.store.RemoveLabel(m.ids, label)
+	}
+	return m.store.AddLabel(m.ids, label)
+}
+
+// KeyHints implements statusbar.Hinter.
+func (m Model) KeyHints() []key.Binding {
+	keyMap := keys.Get().Label
+	return []key.Binding{keyMap.Save, keyMap.Close}
+}
+
+// Size implements overlay.ModalModel.
+func (m Model) Size() (width int, height int) {
+	content := m.View()
+	return lipgloss.Width(content), lipgloss.Height(content)
+}
+
+func labelStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Get().Accent).
+		Padding(1, 2)
+}
+
+func (m Model) View() string {
+	title := "Label " + m.ids[0].String()
+	if len(m.ids) > 1 {
+		title = fmt.Sprintf("Label %d tickets", len(m.ids))
+	}
+	content := lipgloss.NewStyle().Width(m.input.Width + 2).Render(m.input.View())
+	return overlay.Place(4, 0, title, labelStyle().Render(content), false)
+}
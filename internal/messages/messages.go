@@ -16,7 +16,18 @@ func Quit() tea.Msg {
 	return QuitMsg{}
 }
 
+// CriticalFailureMsg reports a failure the program can not recover from,
+// causing it to quit
 type CriticalFailureMsg struct {
 	Err          error
 	FriendlyText string
 }
+
+// ErrorMsg reports a recoverable failure, the program keeps running and the
+// failure is shown until it is dismissed or retried
+type ErrorMsg struct {
+	Err          error
+	FriendlyText string
+	// Retry runs the failed operation again, nil when it can not be retried
+	Retry tea.Cmd
+}
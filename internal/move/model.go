@@ -0,0 +1,218 @@
+// Package move lets the user pick the column and position to move a ticket to
+package move
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Kavantix/kantui/internal/keys"
+	"github.com/Kavantix/kantui/internal/messages"
+	"github.com/Kavantix/kantui/internal/overlay"
+	"github.com/Kavantix/kantui/internal/statusbar"
+	"github.com/Kavantix/kantui/internal/theme"
+	"github.com/Kavantix/kantui/internal/ticket"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+type Model struct {
+	// moving are the tickets that are moved, which are all in the same column
+	moving  []ticket.Ticket
+	tickets []ticket.Ticket
+	store   ticket.Store
+	limit   Limit
+
+	// column is the index of the selected status
+	column int
+	cursor int
+
+	maxWidth  int
+	maxHeight int
+}
+
+// assert
+var _ overlay.ModalModel = Model{}
+var _ overlay.Sizeable = Model{}
+var _ statusbar.Hinter = Model{}
+
+// Limit guards moving the tickets to the status, like the wip limits of the columns,
+// returning the move when it is allowed
+type Limit func(status ticket.Status, tickets []ticket.Ticket, move tea.Cmd) tea.Cmd
+
+// Show opens the picker for the tickets,
+// the positions are based on the committed tickets so they are loaded first
+func Show(moving []ticket.Ticket, store ticket.Store, limit Limit) tea.Cmd {
+	return func() tea.Msg {
+		loaded, err := ticket.Await(store.Load)
+		if err != nil {
+			return messages.ErrorMsg{
+				Err:          err,
+				FriendlyText: "Failed to load tickets",
+				Retry:        Show(moving, store, limit),
+			}
+		}
+		m := Model{
+			moving:  moving,
+			tickets: loaded.Tickets,
+			store:   store,
+			limit:   limit,
+		}
+		for i, status := range ticket.Statusses {
+			if status == moving[0].Status {
+				m.column = i
+			}
+		}
+		return m
+	}
+}
+
+func (m Model) isMoving(id ticket.TicketId) bool {
+	for _, t := range m.moving {
+		if t.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (m Model) movingIds() []ticket.TicketId {
+	ids := make([]ticket.TicketId, len(m.moving))
+	for i, t := range m.moving {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
+type option struct {
+	label    string
+	position ticket.Position
+}
+
+// options lists the positions in the selected column,
+// being after the last ticket is the same as being at the bottom so it is left out
+func (m Model) options() []option {
+	status := ticket.Statusses[m.column]
+	var others []ticket.Ticket
+	for _, t := range m.tickets {
+		if t.Status == status && !m.isMoving(t.ID) {
+			others = append(others, t)
+		}
+	}
+	options := []option{{"Top", ticket.AtTop()}}
+	for i, other := range others {
+		if i == len(others)-1 {
+			options = append(options, option{"Bottom", ticket.AtBottom()})
+			break
+		}
+		label := "After " + ticket.IdStyle().Render(other.ID.String()) + " " + string(other.Title)
+		options = append(options, option{label, ticket.AfterTicket(other.ID)})
+	}
+	return options
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		keyMap := keys.Get().Move
+		switch {
+		case key.Matches(msg, keyMap.Up):
+			m.cursor = max(0, m.cursor-1)
+		case key.Matches(msg, keyMap.Down):
+			m.cursor = min(len(m.options())-1, m.cursor+1)
+		case key.Matches(msg, keyMap.PreviousColumn):
+			m.column = max(0, m.column-1)
+			m.cursor = 0
+		case key.Matches(msg, keyMap.NextColumn):
+			m.column = min(len(ticket.Statusses)-1, m.column+1)
+			m.cursor = 0
+		case key.Matches(msg, keyMap.Confirm):
+			option := m.options()[m.cursor]
+			status := ticket.Statusses[m.column]
+			// The picker is closed first, so a confirmation of the limit is shown on top of the board
+			return m, tea.Sequence(
+				messages.CloseModal,
+				m.limit(status, m.moving, m.store.MoveTickets(m.movingIds(), status, option.position)),
+			)
+		case key.Matches(msg, keyMap.Close):
+			return m, messages.CloseModal
+		}
+	}
+	return m, nil
+}
+
+// KeyHints implements statusbar.Hinter.
+func (m Model) KeyHints() []key.Binding {
+	keyMap := keys.Get().Move
+	return []key.Binding{keyMap.Up, keyMap.Down, keyMap.PreviousColumn, keyMap.NextColumn, keyMap.Confirm, keyMap.Close}
+}
+
+// SetSize implements overlay.Sizeable.
+func (m Model) SetSize(width, height int) overlay.ModalModel {
+	m.maxWidth = width
+	m.maxHeight = height
+	return m
+}
+
+// Size implements overlay.ModalModel.
+func (m Model) Size() (width int, height int) {
+	content := m.View()
+	return lipgloss.Width(content), lipgloss.Height(content)
+}
+
+func moveStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Get().Accent).
+		Padding(1, 2)
+}
+
+func (m Model) View() string {
+	theme := theme.Get()
+	style := moveStyle()
+	frameWidth, frameHeight := style.GetFrameSize()
+	width := 56
+	if m.maxWidth > 0 {
+		width = min(width, m.maxWidth-frameWidth)
+	}
+
+	var columns []string
+	for i, status := range ticket.Statusses {
+		columnStyle := lipgloss.NewStyle().Padding(0, 1).Foreground(theme.Muted)
+		if i == m.column {
+			columnStyle = columnStyle.
+				Foreground(theme.AccentText).
+				Background(theme.Accent)
+		}
+		columns = append(columns, columnStyle.Render(status.ColumnTitle()))
+	}
+
+	options := m.options()
+	// Only show the options around the cursor when they do not fit
+	visible := len(options)
+	if m.maxHeight > 0 {
+		visible = max(1, min(visible, m.maxHeight-frameHeight-2))
+	}
+	start := min(max(0, m.cursor-visible/2), len(options)-visible)
+	lines := []string{strings.Join(columns, " "), ""}
+	for i, option := range options[start : start+visible] {
+		prefix := "  "
+		optionStyle := lipgloss.NewStyle().Foreground(theme.Text)
+		if start+i == m.cursor {
+			prefix = "▸ "
+			optionStyle = optionStyle.Foreground(theme.Selected).Bold(true)
+		}
+		lines = append(lines, ansi.Truncate(prefix+optionStyle.Render(option.label), width, "…"))
+	}
+	content := lipgloss.NewStyle().Width(width).Render(strings.Join(lines, "\n"))
+	title := fmt.Sprintf("Move %s to…", m.moving[0].ID)
+	if len(m.moving) > 1 {
+		title = fmt.Sprintf("Move %d tickets to…", len(m.moving))
+	}
+	return overlay.Place(4, 0, title, style.Render(content), false)
+}
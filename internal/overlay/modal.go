@@ -24,6 +24,14 @@ func (m Model) Focused() bool {
 	return len(m.modals) > 0
 }
 
+// Top returns the modal that receives input, nil when no modal is shown
+func (m Model) Top() ModalModel {
+	if len(m.modals) == 0 {
+		return nil
+	}
+	return m.modals[len(m.modals)-1]
+}
+
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
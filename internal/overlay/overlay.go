@@ -3,6 +3,7 @@ package overlay
 import (
 	"strings"
 
+	"github.com/Kavantix/kantui/internal/theme"
 	"github.com/charmbracelet/lipgloss"
 	charmansi "github.com/charmbracelet/x/ansi"
 	"github.com/muesli/ansi"
@@ -43,7 +44,7 @@ func Place(
 	if shadow {
 		var shadowbg string = ""
 		shadowchar := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#333333")).
+			Foreground(theme.Get().Shadow).
 			Render("░")
 		for i := 0; i <= fgHeight; i++ {
 			if i == 0 {
@@ -162,10 +163,10 @@ func (w whitespace) render(width int) string {
 	return w.style.Styled(b.String())
 }
 
-var dimmBackgroundStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
-
 func DimmBackground(bg string) string {
-	return dimmBackgroundStyle.Render(charmansi.Strip(bg))
+	return lipgloss.NewStyle().
+		Foreground(theme.Get().Dimmed).
+		Render(charmansi.Strip(bg))
 }
 
 // WhitespaceOption sets a styling rule for rendering whitespace.
@@ -0,0 +1,48 @@
+// Package project finds the board that belongs to the current directory
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Dir is the name of the folder that holds the board of a project
+const Dir = ".kantui"
+
+// DbFile returns the database of the project in the given folder
+func DbFile(projectDir string) string {
+	return filepath.Join(projectDir, Dir, "kantui.sqlite3")
+}
+
+// Find walks up from the given folder to find the closest folder that contains a board,
+// empty when there is none
+func Find(from string) string {
+	dir, err := filepath.Abs(from)
+	if err != nil {
+		return ""
+	}
+	for {
+		info, err := os.Stat(filepath.Join(dir, Dir))
+		if err == nil && info.IsDir() {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// Init creates the board folder in the given folder
+func Init(projectDir string) error {
+	dir := filepath.Join(projectDir, Dir)
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("%s already contains a board", projectDir)
+	}
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package query
+
+import (
+	"github.com/Kavantix/kantui/internal/keys"
+	"github.com/Kavantix/kantui/internal/messages"
+	"github.com/Kavantix/kantui/internal/overlay"
+	"github.com/Kavantix/kantui/internal/statusbar"
+	"github.com/Kavantix/kantui/internal/theme"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ApplyMsg is sent when a valid query is entered, the board only shows the
+// tickets that match it
+type ApplyMsg struct {
+	Query Query
+}
+
+type Model struct {
+	input textinput.Model
+	err   error
+}
+
+// assert
+var _ overlay.ModalModel = Model{}
+var _ statusbar.Hinter = Model{}
+
+// Show opens the editor for the query that is applied to the board
+func Show(current Query) tea.Cmd {
+	return func() tea.Msg {
+		input := textinput.New()
+		input.Placeholder = `label:bug -status:done id:>10 "login page"`
+		input.Prompt = "> "
+		input.Width = 56
+		input.SetValue(current.String())
+		input.Focus()
+		return Model{input: input}
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		keyMap := keys.Get().Query
+		switch {
+		case key.Matches(msg, keyMap.Apply):
+			query, err := Parse(m.input.Value())
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			return m, tea.Batch(
+				messages.CloseModal,
+				func() tea.Msg { return ApplyMsg{Query: query} },
+			)
+		case key.Matches(msg, keyMap.Close):
+			return m, messages.CloseModal
+		}
+	}
+	value := m.input.Value()
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() != value {
+		_, m.err = Parse(m.input.Value())
+	}
+	return m, cmd
+}
+
+// KeyHints implements statusbar.Hinter.
+func (m Model) KeyHints() []key.Binding {
+	keyMap := keys.Get().Query
+	return []key.Binding{keyMap.Apply, keyMap.Close}
+}
+
+// Size implements overlay.ModalModel.
+func (m Model) Size() (width int, height int) {
+	content := m.View()
+	return lipgloss.Width(content), lipgloss.Height(content)
+}
+
+func queryStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Get().Accent).
+		Padding(1, 2)
+}
+
+func (m Model) View() string {
+	theme := theme.Get()
+	width := m.input.Width + lipgloss.Width(m.input.Prompt) + 1
+	help := lipgloss.NewStyle().
+		Foreground(theme.Muted).
+		Render("Fields are status, label, id, title and description, an empty query shows all tickets")
+	if m.err != nil {
+		help = lipgloss.NewStyle().Foreground(theme.Error).Render(m.err.Error())
+	}
+	content := lipgloss.NewStyle().
+		Width(width).
+		Render(lipgloss.JoinVertical(lipgloss.Left, m.input.View(), "", help))
+	return overlay.Place(4, 0, "Filter board", queryStyle().Render(content), false)
+}
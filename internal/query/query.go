@@ -0,0 +1,357 @@
+// Package query parses and evaluates filter expressions like
+// `label:bug -status:done id:>10 "login page"` over tickets
+package query
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Kavantix/kantui/internal/ticket"
+)
+
+// Query matches the tickets that match all of its terms,
+// the zero value matches all tickets
+type Query struct {
+	source string
+	terms  []term
+}
+
+type field int
+
+const (
+	// text matches the key, title, description and labels
+	text field = iota
+	status
+	label
+	id
+	title
+	description
+	priority
+	due
+)
+
+var fields = map[string]field{
+	"status":      status,
+	"s":           status,
+	"label":       label,
+	"l":           label,
+	"id":          id,
+	"key":         id,
+	"title":       title,
+	"t":           title,
+	"description": description,
+	"desc":        description,
+	"d":           description,
+	"priority":    priority,
+	"p":           priority,
+	"due":         due,
+}
+
+type operator int
+
+const (
+	equal operator = iota
+	less
+	lessOrEqual
+	greater
+	greaterOrEqual
+)
+
+type term struct {
+	field    field
+	operator operator
+	negated  bool
+	// value is lower case for text, only one of the parsed values is used
+	value  string
+	status ticket.Status
+	id     int64
+	// date is the due date to compare with, when it is zero days is relative to today
+	date time.Time
+	days int
+}
+
+// now returns the current time, due dates like 7d are relative to its day
+var now = time.Now
+
+// Parse reads a query from space separated terms.
+//
+// A term is a word or a quoted phrase that is searched for in the key, title,
+// description and labels, or a field:value pair.
+// Fields are status, label, id, title, description, priority and due, #bug is short for label:bug.
+// Ids, priorities and due dates can be compared with <, <=, > and >=, and a - in front of a term negates it.
+// A higher priority is greater, so priority:>=high also matches critical tickets,
+// but names like high can not be compared to levels like P1.
+// Due dates are dates like 2024-05-01, today, tomorrow, yesterday or days from today like 7d or 2w.
+func Parse(source string) (Query, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return Query{}, err
+	}
+	query := Query{source: strings.TrimSpace(source)}
+	for _, token := range tokens {
+		term, err := parseTerm(token)
+		if err != nil {
+			return Query{}, err
+		}
+		query.terms = append(query.terms, term)
+	}
+	return query, nil
+}
+
+// token is a term before its field and value are parsed,
+// quoted parts are not split on a colon
+type token struct {
+	negated bool
+	name    string
+	value   string
+	quoted  bool
+	hasName bool
+}
+
+func tokenize(source string) ([]token, error) {
+	var tokens []token
+	runes := []rune(source)
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+		var t token
+		if runes[i] == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) {
+			t.negated = true
+			i++
+		}
+		start := i
+		for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '"' && runes[i] != ':' {
+			i++
+		}
+		if i < len(runes) && runes[i] == ':' {
+			t.name = string(runes[start:i])
+			t.hasName = true
+			i++
+			start = i
+		}
+		if i < len(runes) && runes[i] == '"' && i == start {
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, errors.New("missing closing quote")
+			}
+			t.value = string(runes[i+1 : end])
+			t.quoted = true
+			i = end + 1
+		} else {
+			for i < len(runes) && !unicode.IsSpace(runes[i]) {
+				i++
+			}
+			t.value = string(runes[start:i])
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens, nil
+}
+
+func parseTerm(token token) (term, error) {
+	t := term{field: text, negated: token.negated}
+	if token.hasName {
+		field, ok := fields[strings.ToLower(token.name)]
+		if !ok {
+			return term{}, fmt.Errorf("unknown field %q, expected status, label, id, title, description, priority or due", token.name)
+		}
+		t.field = field
+	} else if !token.quoted && strings.HasPrefix(token.value, "#") {
+		t.field = label
+		token.value = token.value[1:]
+	}
+
+	value := token.value
+	if t.field == id || t.field == priority || t.field == due {
+		for _, operator := range []struct {
+			prefix   string
+			operator operator
+		}{{">=", greaterOrEqual}, {"<=", lessOrEqual}, {">", greater}, {"<", less}, {"=", equal}} {
+			if rest, ok := strings.CutPrefix(value, operator.prefix); ok {
+				value = rest
+				t.operator = operator.operator
+				break
+			}
+		}
+	}
+	if value == "" {
+		if token.hasName {
+			return term{}, fmt.Errorf("missing value for %s", token.name)
+		}
+		return term{}, errors.New("missing value")
+	}
+
+	switch t.field {
+	case status:
+		status, err := ticket.ParseStatus(value)
+		if err != nil {
+			return term{}, err
+		}
+		t.status = status
+	case id:
+		id, err := ticket.ParseTicketId(value)
+		if err != nil {
+			return term{}, err
+		}
+		t.id = id.Number()
+	case label:
+		t.value = strings.ToLower(strings.TrimPrefix(value, "#"))
+	case priority:
+		t.value = strings.TrimPrefix(strings.ToLower(value), ticket.PriorityPrefix)
+		if t.operator != equal && !ticket.ComparablePriority(t.value) {
+			return term{}, fmt.Errorf("can not compare priority %q, expected a name like high, a level like P1 or a letter like A", value)
+		}
+	case due:
+		date, days, err := parseDate(value)
+		if err != nil {
+			return term{}, err
+		}
+		t.date = date
+		t.days = days
+	default:
+		t.value = strings.ToLower(value)
+	}
+	return t, nil
+}
+
+// parseDate reads an absolute date like 2024-05-01 or the amount of days relative to today
+func parseDate(value string) (time.Time, int, error) {
+	switch strings.ToLower(value) {
+	case "today":
+		return time.Time{}, 0, nil
+	case "tomorrow":
+		return time.Time{}, 1, nil
+	case "yesterday":
+		return time.Time{}, -1, nil
+	}
+	if date, err := time.ParseInLocation(ticket.DateFormat, value, time.Local); err == nil {
+		return date, 0, nil
+	}
+	for unit, days := range map[string]int{"d": 1, "w": 7} {
+		if number, ok := strings.CutSuffix(strings.ToLower(value), unit); ok {
+			if amount, err := strconv.Atoi(number); err == nil {
+				return time.Time{}, amount * days, nil
+			}
+		}
+	}
+	return time.Time{}, 0, fmt.Errorf("invalid due date %q, expected a date like 2024-05-01, today or a number of days like 7d", value)
+}
+
+// String returns the query as it was typed
+func (q Query) String() string {
+	return q.source
+}
+
+// IsEmpty is true when the query matches all tickets
+func (q Query) IsEmpty() bool {
+	return len(q.terms) == 0
+}
+
+// Match reports whether the ticket matches all terms of the query
+func (q Query) Match(t ticket.Ticket) bool {
+	for _, term := range q.terms {
+		if term.match(t) == term.negated {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the tickets that match the query, keeping their order
+func (q Query) Filter(tickets []ticket.Ticket) []ticket.Ticket {
+	if q.IsEmpty() {
+		return tickets
+	}
+	var matching []ticket.Ticket
+	for _, t := range tickets {
+		if q.Match(t) {
+			matching = append(matching, t)
+		}
+	}
+	return matching
+}
+
+func (term term) match(t ticket.Ticket) bool {
+	contains := func(value string) bool {
+		return strings.Contains(strings.ToLower(value), term.value)
+	}
+	switch term.field {
+	case status:
+		return t.Status == term.status
+	case label:
+		for _, label := range t.Labels {
+			if strings.ToLower(string(label)) == term.value {
+				return true
+			}
+		}
+		return false
+	case id:
+		return term.operator.holds(cmp.Compare(t.ID.Number(), term.id))
+	case priority:
+		// Tickets without a priority never match, not even a comparison
+		current := t.Priority()
+		if current == "" {
+			return false
+		}
+		if term.operator == equal {
+			return strings.EqualFold(current, term.value)
+		}
+		compared, ok := ticket.ComparePriorityLevel(current, term.value)
+		return ok && term.operator.holds(compared)
+	case due:
+		date, ok := t.Due()
+		if !ok {
+			return false
+		}
+		return term.operator.holds(date.Compare(term.dueDate()))
+	case title:
+		return contains(string(t.Title))
+	case description:
+		return contains(string(t.Description))
+	default:
+		if contains(t.ID.String()) || contains(string(t.Title)) || contains(string(t.Description)) {
+			return true
+		}
+		for _, label := range t.Labels {
+			if contains(string(label)) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// dueDate is the date the term compares the due date of tickets with
+func (term term) dueDate() time.Time {
+	if !term.date.IsZero() {
+		return term.date
+	}
+	year, month, day := now().Date()
+	return time.Date(year, month, day+term.days, 0, 0, 0, 0, time.Local)
+}
+
+// holds reports whether the result of comparing a ticket to the value of the term satisfies the operator
+func (o operator) holds(compared int) bool {
+	switch o {
+	case less:
+		return compared < 0
+	case lessOrEqual:
+		return compared <= 0
+	case greater:
+		return compared > 0
+	case greaterOrEqual:
+		return compared >= 0
+	default:
+		return compared == 0
+	}
+}
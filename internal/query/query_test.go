@@ -0,0 +1,139 @@
+package query
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kavantix/kantui/internal/ticket"
+)
+
+func newTicket(t *testing.T, id string, status ticket.Status, title, description string, labels ...string) ticket.Ticket {
+	t.Helper()
+	ticketId, err := ticket.ParseTicketId(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := ticket.Ticket{
+		ID:          ticketId,
+		Status:      status,
+		Title:       ticket.TicketTitle(title),
+		Description: ticket.TicketDescription(description),
+	}
+	for _, label := range labels {
+		result.Labels = append(result.Labels, ticket.TicketLabel(label))
+	}
+	return result
+}
+
+func TestMatch(t *testing.T) {
+	// Today is fixed so relative due dates give the same result every day
+	now = func() time.Time { return time.Date(2024, 5, 10, 15, 0, 0, 0, time.Local) }
+	t.Cleanup(func() { now = time.Now })
+
+	tickets := []ticket.Ticket{
+		newTicket(t, "TK-1", ticket.Todo, "Fix login page", "The session expires", "bug", "priority:P1", "due:2024-05-12"),
+		newTicket(t, "TK-2", ticket.InProgress, "Write the docs", "", "documentation", "priority:high", "due:2024-05-09"),
+		newTicket(t, "TK-3", ticket.Done, "Login with token", "", "bug", "priority:P10", "due:2024-06-01"),
+		newTicket(t, "TK-10", ticket.Todo, "Setup CI", "Run the tests on every push", "priority:low"),
+		newTicket(t, "TK-11", ticket.Todo, "Export to todo.txt", "", "priority:A", "Epic:Export"),
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"TK-1", "TK-2", "TK-3", "TK-10", "TK-11"}},
+		{"login", []string{"TK-1", "TK-3"}},
+		{`"login page"`, []string{"TK-1"}},
+		{"session", []string{"TK-1"}},
+		{"tk-1", []string{"TK-1", "TK-10", "TK-11"}},
+		{"label:bug", []string{"TK-1", "TK-3"}},
+		{"#BUG", []string{"TK-1", "TK-3"}},
+		{"l:epic:export", []string{"TK-11"}},
+		{"-label:bug", []string{"TK-2", "TK-10", "TK-11"}},
+		{"status:todo", []string{"TK-1", "TK-10", "TK-11"}},
+		{"status:in-progress", []string{"TK-2"}},
+		{"-status:Done login", []string{"TK-1"}},
+		{"id:TK-3", []string{"TK-3"}},
+		{"id:>=10", []string{"TK-10", "TK-11"}},
+		{"id:<3", []string{"TK-1", "TK-2"}},
+		{"id:<=3 id:>1", []string{"TK-2", "TK-3"}},
+		{"title:login", []string{"TK-1", "TK-3"}},
+		{"desc:tests", []string{"TK-10"}},
+		{"priority:p1", []string{"TK-1"}},
+		{"priority:>=P1", []string{"TK-1"}},
+		{"p:<P1", []string{"TK-3"}},
+		{"priority:>P10", []string{"TK-1"}},
+		{"priority:>=high", []string{"TK-2"}},
+		{"priority:<high", []string{"TK-10"}},
+		{"priority:<=B", []string{}},
+		{"priority:>=B", []string{"TK-11"}},
+		{"-priority:>=P1", []string{"TK-2", "TK-3", "TK-10", "TK-11"}},
+		{"due:<7d", []string{"TK-1", "TK-2"}},
+		{"due:<today", []string{"TK-2"}},
+		{"due:>=today", []string{"TK-1", "TK-3"}},
+		{"due:2024-05-12", []string{"TK-1"}},
+		{"due:>2w", []string{"TK-3"}},
+		{"due:<=yesterday", []string{"TK-2"}},
+		{"due:tomorrow", []string{}},
+		{"label:bug priority:>=P1 due:<7d -status:Done login", []string{"TK-1"}},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			query, err := Parse(test.query)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", test.query, err)
+			}
+			got := []string{}
+			for _, match := range query.Filter(tickets) {
+				got = append(got, match.ID.String())
+			}
+			if !slices.Equal(got, test.want) {
+				t.Errorf("Parse(%q) matched %v, want %v", test.query, got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		err   string
+	}{
+		{`"login`, "missing closing quote"},
+		{"assignee:bob", `unknown field "assignee"`},
+		{"status:", "missing value for status"},
+		{"status:later", `invalid status "later"`},
+		{"id:>abc", `invalid ticket id "abc"`},
+		{"priority:>=soon", `can not compare priority "soon"`},
+		{"due:next-week", `invalid due date "next-week"`},
+		{"#", "missing value"},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			_, err := Parse(test.query)
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("Parse(%q) returned error %v, want %q", test.query, err, test.err)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	query, err := Parse("  label:bug  login ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query.String() != "label:bug  login" {
+		t.Errorf("String() = %q, want the trimmed source", query.String())
+	}
+	if query.IsEmpty() {
+		t.Error("IsEmpty() = true for a query with terms")
+	}
+	empty, _ := Parse(" ")
+	if !empty.IsEmpty() {
+		t.Error("IsEmpty() = false for a query without terms")
+	}
+}
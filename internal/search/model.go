@@ -0,0 +1,358 @@
+// Package search fuzzy finds tickets across all columns
+package search
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Kavantix/kantui/internal/keys"
+	"github.com/Kavantix/kantui/internal/messages"
+	"github.com/Kavantix/kantui/internal/overlay"
+	"github.com/Kavantix/kantui/internal/statusbar"
+	"github.com/Kavantix/kantui/internal/theme"
+	"github.com/Kavantix/kantui/internal/ticket"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sahilm/fuzzy"
+)
+
+// SelectMsg is sent when a match is chosen, the board focuses the ticket
+type SelectMsg struct {
+	Ticket ticket.Ticket
+}
+
+type Model struct {
+	store   ticket.Store
+	tickets []ticket.Ticket
+	input   textinput.Model
+	// fullText are the results of the full text search for the query,
+	// which arrive after the fuzzy matches
+	fullText []ticket.SearchResult
+	entries  []entry
+	cursor   int
+
+	maxWidth  int
+	maxHeight int
+}
+
+// entry is a ticket in the results,
+// matched are the indexes of the fuzzy matched characters in its heading
+type entry struct {
+	ticket  ticket.Ticket
+	matched []int
+	snippet []ticket.SnippetPart
+}
+
+// fullTextLimit is the maximum amount of full text results shown below the fuzzy matches
+const fullTextLimit = 50
+
+// assert
+var _ overlay.ModalModel = Model{}
+var _ overlay.Sizeable = Model{}
+var _ statusbar.Hinter = Model{}
+
+// Show opens the search for all tickets of the board,
+// the columns can be filtered so the tickets are loaded from the store
+func Show(store ticket.Store) tea.Cmd {
+	return func() tea.Msg {
+		loaded, err := ticket.Await(store.Load)
+		if err != nil {
+			return messages.ErrorMsg{
+				Err:          err,
+				FriendlyText: "Failed to load tickets",
+				Retry:        Show(store),
+			}
+		}
+		input := textinput.New()
+		input.Placeholder = "Search by key, title, description or #label"
+		input.Prompt = "> "
+		input.Focus()
+		m := Model{
+			store:   store,
+			tickets: loaded.Tickets,
+			input:   input,
+		}
+		m.entries = m.find()
+		return m
+	}
+}
+
+// source is what the query is fuzzy matched against,
+// the key and title come first so they can be highlighted in the results.
+// Descriptions are left to the full text search.
+type source []ticket.Ticket
+
+func (s source) String(i int) string {
+	t := s[i]
+	value := strings.Builder{}
+	value.WriteString(heading(t))
+	for _, label := range t.Labels {
+		value.WriteString(" #")
+		value.WriteString(string(label))
+	}
+	return value.String()
+}
+
+func (s source) Len() int {
+	return len(s)
+}
+
+func heading(t ticket.Ticket) string {
+	return t.ID.String() + " " + string(t.Title)
+}
+
+// find lists the fuzzy matches followed by the other full text results,
+// without a query all tickets are listed in board order
+func (m Model) find() []entry {
+	query := strings.TrimSpace(m.input.Value())
+	if query == "" {
+		entries := make([]entry, len(m.tickets))
+		for i, t := range m.tickets {
+			entries[i] = entry{ticket: t}
+		}
+		return entries
+	}
+	snippets := map[ticket.TicketId][]ticket.SnippetPart{}
+	for _, result := range m.fullText {
+		snippets[result.Ticket.ID] = result.Snippet
+	}
+	var entries []entry
+	for _, match := range fuzzy.FindFrom(query, source(m.tickets)) {
+		t := m.tickets[match.Index]
+		entries = append(entries, entry{
+			ticket:  t,
+			matched: match.MatchedIndexes,
+			snippet: snippets[t.ID],
+		})
+		delete(snippets, t.ID)
+	}
+	for _, result := range m.fullText {
+		if snippet, ok := snippets[result.Ticket.ID]; ok {
+			entries = append(entries, entry{ticket: result.Ticket, snippet: snippet})
+		}
+	}
+	return entries
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		keyMap := keys.Get().Search
+		switch {
+		case key.Matches(msg, keyMap.Up):
+			m.cursor = max(0, m.cursor-1)
+			return m, nil
+		case key.Matches(msg, keyMap.Down):
+			m.cursor = max(0, min(len(m.entries)-1, m.cursor+1))
+			return m, nil
+		case key.Matches(msg, keyMap.Select):
+			if len(m.entries) == 0 {
+				return m, nil
+			}
+			selected := m.entries[m.cursor].ticket
+			return m, tea.Batch(
+				messages.CloseModal,
+				func() tea.Msg { return SelectMsg{Ticket: selected} },
+			)
+		case key.Matches(msg, keyMap.Close):
+			return m, messages.CloseModal
+		}
+	case ticket.SearchResultsMsg:
+		// Results of a query that has been changed since are dropped
+		if msg.Query != m.input.Value() {
+			return m, nil
+		}
+		m.fullText = msg.Results
+		m.entries = m.find()
+		m.cursor = min(m.cursor, max(0, len(m.entries)-1))
+		return m, nil
+	}
+	query := m.input.Value()
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() != query {
+		m.fullText = nil
+		m.entries = m.find()
+		m.cursor = 0
+		if strings.TrimSpace(m.input.Value()) != "" {
+			cmd = tea.Batch(cmd, m.store.Search(m.input.Value(), fullTextLimit))
+		}
+	}
+	return m, cmd
+}
+
+// KeyHints implements statusbar.Hinter.
+func (m Model) KeyHints() []key.Binding {
+	keyMap := keys.Get().Search
+	return []key.Binding{keyMap.Up, keyMap.Down, keyMap.Select, keyMap.Close}
+}
+
+// SetSize implements overlay.Sizeable.
+func (m Model) SetSize(width, height int) overlay.ModalModel {
+	m.maxWidth = width
+	m.maxHeight = height
+	return m
+}
+
+// Size implements overlay.ModalModel.
+func (m Model) Size() (width int, height int) {
+	content := m.View()
+	return lipgloss.Width(content), lipgloss.Height(content)
+}
+
+func searchStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Get().Accent).
+		Padding(1, 2)
+}
+
+// highlighted renders the key and title of an entry,
+// the matched characters in the labels are not shown
+func highlighted(entry entry, style lipgloss.Style) string {
+	text := heading(entry.ticket)
+	var indices []int
+	for _, index := range entry.matched {
+		if index < len(text) {
+			// The matched indexes are bytes while the styles apply to runes
+			indices = append(indices, utf8.RuneCountInString(text[:index]))
+		}
+	}
+	matched := style.Foreground(theme.Get().Highlight).Underline(true)
+	return lipgloss.StyleRunes(text, indices, matched, style)
+}
+
+func (m Model) viewResults(width, height int) string {
+	theme := theme.Get()
+	if len(m.entries) == 0 {
+		return lipgloss.NewStyle().Foreground(theme.Muted).Render("No tickets found")
+	}
+	// Only show the entries around the cursor when they do not fit
+	visible := min(len(m.entries), height)
+	start := min(max(0, m.cursor-visible/2), len(m.entries)-visible)
+	lines := make([]string, 0, visible)
+	for i, entry := range m.entries[start : start+visible] {
+		prefix := "  "
+		style := lipgloss.NewStyle().Foreground(theme.Text)
+		if start+i == m.cursor {
+			prefix = "▸ "
+			style = style.Foreground(theme.Selected).Bold(true)
+		}
+		line := highlighted(entry, style)
+		lines = append(lines, ansi.Truncate(prefix+line, width, "…"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m Model) viewPreview(width, height int) string {
+	if len(m.entries) == 0 {
+		return ""
+	}
+	theme := theme.Get()
+	entry := m.entries[m.cursor]
+	t := entry.ticket
+	lines := []string{
+		ticket.IdStyle().Render(t.ID.String()) + " " +
+			lipgloss.NewStyle().Foreground(theme.Muted).Render("in "+t.Status.ColumnTitle()),
+		lipgloss.NewStyle().Foreground(theme.Title).Bold(true).Width(width).Render(string(t.Title)),
+	}
+	if len(t.Labels) > 0 {
+		labels := make([]string, len(t.Labels))
+		for i, label := range t.Labels {
+			labels[i] = "#" + string(label)
+		}
+		lines = append(lines, ticket.LabelStyle().Width(width).Render(strings.Join(labels, " ")))
+	}
+	if snippet := viewSnippet(entry, width); snippet != "" {
+		lines = append(lines, "", snippet)
+	}
+	if t.Description != "" {
+		lines = append(lines, "", lipgloss.NewStyle().
+			Foreground(theme.Description).
+			Width(width).
+			Render(string(t.Description)))
+	}
+	preview := strings.Split(strings.Join(lines, "\n"), "\n")
+	if len(preview) > height {
+		preview = append(preview[:height-1], lipgloss.NewStyle().Foreground(theme.Muted).Render("…"))
+	}
+	return strings.Join(preview, "\n")
+}
+
+// viewSnippet shows where the full text search matched,
+// unless that is the key or title, or a short description, which are shown already
+func viewSnippet(entry entry, width int) string {
+	theme := theme.Get()
+	plain := strings.Builder{}
+	snippet := strings.Builder{}
+	matchStyle := lipgloss.NewStyle().Foreground(theme.Highlight).Bold(true)
+	textStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	for _, part := range entry.snippet {
+		text := strings.ReplaceAll(part.Text, "\n", " ")
+		plain.WriteString(text)
+		if part.Match {
+			snippet.WriteString(matchStyle.Render(text))
+		} else {
+			snippet.WriteString(textStyle.Render(text))
+		}
+	}
+	matched := strings.Trim(plain.String(), " …")
+	switch matched {
+	case "", entry.ticket.ID.String(), string(entry.ticket.Title):
+		return ""
+	}
+	description := strings.ReplaceAll(string(entry.ticket.Description), "\n", " ")
+	if len(description) <= 3*width && strings.Contains(description, matched) {
+		return ""
+	}
+	return lipgloss.NewStyle().Width(width).Render(snippet.String())
+}
+
+func (m Model) View() string {
+	theme := theme.Get()
+	style := searchStyle()
+	frameWidth, frameHeight := style.GetFrameSize()
+	width, height := 96, 14
+	if m.maxWidth > 0 {
+		width = min(width, m.maxWidth-frameWidth)
+	}
+	if m.maxHeight > 0 {
+		// The query and the line below it are always shown
+		height = max(1, min(height, m.maxHeight-frameHeight-2))
+	}
+	m.input.Width = max(1, width-lipgloss.Width(m.input.Prompt)-1)
+
+	// The preview is left out when there is no room next to the results
+	resultsWidth := width
+	previewWidth := width * 5 / 12
+	if previewWidth >= 24 {
+		resultsWidth = width - previewWidth - 3
+	}
+	results := lipgloss.NewStyle().
+		Width(resultsWidth).
+		Height(height).
+		Render(m.viewResults(resultsWidth, height))
+	body := results
+	if resultsWidth < width {
+		separator := lipgloss.NewStyle().
+			Foreground(theme.Border).
+			Padding(0, 1).
+			Render(strings.Repeat("│\n", height-1) + "│")
+		preview := lipgloss.NewStyle().
+			Width(previewWidth).
+			Height(height).
+			Render(m.viewPreview(previewWidth, height))
+		body = lipgloss.JoinHorizontal(lipgloss.Top, results, separator, preview)
+	}
+	content := lipgloss.NewStyle().
+		Width(width).
+		Render(lipgloss.JoinVertical(lipgloss.Left, m.input.View(), "", body))
+	return overlay.Place(4, 0, "Search", style.Render(content), false)
+}
@@ -0,0 +1,211 @@
+// Package server exposes the tickets of a board as a JSON API
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Kavantix/kantui/internal/ticket"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type server struct {
+	// mutex serializes requests so every request operates on freshly loaded tickets
+	mutex sync.Mutex
+	store ticket.Store
+	token string
+}
+
+// New returns a handler that serves the tickets of the store.
+// When token is not empty every request needs to send it as a bearer token.
+// Requests from web pages are rejected, so sites opened in a browser can not reach the board.
+func New(store ticket.Store, token string) http.Handler {
+	s := &server{
+		store: store,
+		token: token,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /tickets", s.listTickets)
+	mux.HandleFunc("POST /tickets", s.createTicket)
+	mux.HandleFunc("GET /tickets/{id}", s.getTicket)
+	mux.HandleFunc("PATCH /tickets/{id}", s.updateTicket)
+	mux.HandleFunc("DELETE /tickets/{id}", s.deleteTicket)
+	mux.HandleFunc("POST /tickets/{id}/move", s.moveTicket)
+	mux.HandleFunc("POST /tickets/{id}/rank", s.rankTicket)
+	return protect(s.authenticate(mux))
+}
+
+type ticketJson struct {
+	ID          string   `json:"id"`
+	Status      string   `json:"status"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Labels      []string `json:"labels"`
+}
+
+func toJson(t ticket.Ticket) ticketJson {
+	labels := []string{}
+	for _, label := range t.Labels {
+		labels = append(labels, string(label))
+	}
+	return ticketJson{
+		ID:          t.ID.String(),
+		Status:      t.Status.String(),
+		Title:       string(t.Title),
+		Description: string(t.Description),
+		Labels:      labels,
+	}
+}
+
+type httpError struct {
+	status int
+	err    error
+}
+
+func (e httpError) Error() string {
+	return e.err.Error()
+}
+
+func badRequest(format string, args ...any) error {
+	return httpError{http.StatusBadRequest, fmt.Errorf(format, args...)}
+}
+
+func (s *server) authenticate(next http.Handler) http.Handler {
+	if s.token == "" {
+		return next
+	}
+	expected := []byte("Bearer " + s.token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			writeError(w, httpError{http.StatusUnauthorized, errors.New("missing or invalid token")})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// protect rejects the requests a web page can make through the browser of the user:
+// cross origin requests and requests for a host name that was pointed at localhost
+func protect(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Origin") != "" {
+			writeError(w, httpError{http.StatusForbidden, errors.New("requests from web pages are not allowed")})
+			return
+		}
+		if !isLocal(r) {
+			writeError(w, httpError{http.StatusForbidden, fmt.Errorf("host %q is not allowed", r.Host)})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isLocal reports whether the request came in over a unix socket or is addressed to a loopback host
+func isLocal(r *http.Request) bool {
+	if addr, ok := r.Context().Value(http.LocalAddrContextKey).(net.Addr); ok && addr.Network() == "unix" {
+		return true
+	}
+	host := r.Host
+	if withoutPort, _, err := net.SplitHostPort(host); err == nil {
+		host = withoutPort
+	}
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	return ip != nil && ip.IsLoopback()
+}
+
+// load reloads the tickets so changes from other processes are not lost,
+// the caller should hold the mutex
+func (s *server) load() ([]ticket.Ticket, error) {
+	msg, err := ticket.Await(s.store.Load)
+	return msg.Tickets, err
+}
+
+// apply runs a store command and responds with the ticket with the given id
+func (s *server) apply(w http.ResponseWriter, id ticket.TicketId, cmd tea.Cmd) {
+	msg, err := ticket.Await(cmd)
+	if errors.Is(err, ticket.ErrNothingChanged) {
+		msg.Tickets, err = s.load()
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	t, err := find(msg.Tickets, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJson(w, http.StatusOK, toJson(t))
+}
+
+func find(tickets []ticket.Ticket, id ticket.TicketId) (ticket.Ticket, error) {
+	index := slices.IndexFunc(tickets, func(t ticket.Ticket) bool { return t.ID == id })
+	if index < 0 {
+		return ticket.Ticket{}, httpError{http.StatusNotFound, fmt.Errorf("ticket %s not found", id)}
+	}
+	return tickets[index], nil
+}
+
+// pathTicket loads the tickets and finds the ticket referenced by the path,
+// the caller should hold the mutex
+func (s *server) pathTicket(r *http.Request) ([]ticket.Ticket, ticket.Ticket, error) {
+	id, err := ticket.ParseTicketId(r.PathValue("id"))
+	if err != nil {
+		return nil, ticket.Ticket{}, httpError{http.StatusNotFound, err}
+	}
+	tickets, err := s.load()
+	if err != nil {
+		return nil, ticket.Ticket{}, err
+	}
+	t, err := find(tickets, id)
+	return tickets, t, err
+}
+
+// readJson decodes the body, which has to be sent as application/json
+// so browsers can not send it without asking the server first
+func readJson(r *http.Request, body any) error {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return httpError{http.StatusUnsupportedMediaType, errors.New("expected a Content-Type of application/json")}
+	}
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(body); err != nil {
+		return badRequest("invalid request body: %w", err)
+	}
+	return nil
+}
+
+func writeJson(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.Error("Failed to write response", slog.String("error", err.Error()))
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var httpErr httpError
+	var limitErr *ticket.WipLimitError
+	if errors.As(err, &httpErr) {
+		status = httpErr.status
+	} else if errors.As(err, &limitErr) {
+		status = http.StatusConflict
+	} else {
+		slog.Error("Request failed", slog.String("error", err.Error()))
+	}
+	writeJson(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,272 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Kavantix/kantui/internal/database"
+	"github.com/Kavantix/kantui/internal/ticket"
+)
+
+const testToken = "secret"
+
+// newTestServer serves a new board with TK-1 and TK-2 in todo and TK-3 in progress
+func newTestServer(t *testing.T) http.Handler {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "board.sqlite3")
+	if err := database.Migrate(file, 0); err != nil {
+		t.Fatal(err)
+	}
+	db, err := database.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := ticket.NewStore(db)
+	_, err = ticket.Await(store.Import([]ticket.Ticket{
+		{Status: ticket.Todo, Title: "First"},
+		{Status: ticket.Todo, Title: "Second", Labels: []ticket.TicketLabel{"bug"}},
+		{Status: ticket.InProgress, Title: "Third"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return New(store, testToken)
+}
+
+func request(t *testing.T, handler http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Host = "localhost:7730"
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func decode[T any](t *testing.T, recorder *httptest.ResponseRecorder) T {
+	t.Helper()
+	var result T
+	if err := json.NewDecoder(recorder.Body).Decode(&result); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return result
+}
+
+func ticketIds(t *testing.T, handler http.Handler, status string) []string {
+	t.Helper()
+	recorder := request(t, handler, "GET", "/tickets?status="+status, "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("listing tickets returned %d: %s", recorder.Code, recorder.Body)
+	}
+	var ids []string
+	for _, t := range decode[[]ticketJson](t, recorder) {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+func TestProtection(t *testing.T) {
+	handler := newTestServer(t)
+	tests := []struct {
+		name   string
+		modify func(*http.Request)
+		want   int
+	}{
+		{"valid", func(r *http.Request) {}, http.StatusOK},
+		{"loopback ip", func(r *http.Request) { r.Host = "127.0.0.1:7730" }, http.StatusOK},
+		{"ipv6 loopback", func(r *http.Request) { r.Host = "[::1]:7730" }, http.StatusOK},
+		{"missing token", func(r *http.Request) { r.Header.Del("Authorization") }, http.StatusUnauthorized},
+		{"wrong token", func(r *http.Request) { r.Header.Set("Authorization", "Bearer guess") }, http.StatusUnauthorized},
+		{"origin", func(r *http.Request) { r.Header.Set("Origin", "https://example.com") }, http.StatusForbidden},
+		{"other host", func(r *http.Request) { r.Host = "attacker.example.com" }, http.StatusForbidden},
+		{"other ip", func(r *http.Request) { r.Host = "192.168.1.10:7730" }, http.StatusForbidden},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/tickets", nil)
+			req.Host = "localhost:7730"
+			req.Header.Set("Authorization", "Bearer "+testToken)
+			test.modify(req)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+			if recorder.Code != test.want {
+				t.Errorf("request returned %d, want %d: %s", recorder.Code, test.want, recorder.Body)
+			}
+		})
+	}
+}
+
+func TestContentType(t *testing.T) {
+	handler := newTestServer(t)
+	for _, contentType := range []string{"", "text/plain", "application/x-www-form-urlencoded"} {
+		req := httptest.NewRequest("POST", "/tickets", strings.NewReader(`{"title": "New"}`))
+		req.Host = "localhost"
+		req.Header.Set("Authorization", "Bearer "+testToken)
+		req.Header.Set("Content-Type", contentType)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		if recorder.Code != http.StatusUnsupportedMediaType {
+			t.Errorf("Content-Type %q returned %d, want %d", contentType, recorder.Code, http.StatusUnsupportedMediaType)
+		}
+	}
+	if ids := ticketIds(t, handler, ""); len(ids) != 3 {
+		t.Errorf("tickets = %v, want no new tickets", ids)
+	}
+}
+
+func TestRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+		want   *ticketJson
+	}{
+		{"get", "GET", "/tickets/tk-2", "", http.StatusOK, &ticketJson{ID: "TK-2", Status: ticket.Todo.String(), Title: "Second", Labels: []string{"bug"}}},
+		{"get missing", "GET", "/tickets/TK-9", "", http.StatusNotFound, nil},
+		{"get invalid id", "GET", "/tickets/abc", "", http.StatusNotFound, nil},
+		{"list invalid status", "GET", "/tickets?status=later", "", http.StatusBadRequest, nil},
+		{
+			"create", "POST", "/tickets", `{"title": " New ", "description": "Details", "status": "in-progress", "labels": ["api"]}`,
+			http.StatusCreated, &ticketJson{ID: "TK-4", Status: ticket.InProgress.String(), Title: "New", Description: "Details", Labels: []string{"api"}},
+		},
+		{"create without title", "POST", "/tickets", `{"title": " "}`, http.StatusBadRequest, nil},
+		{"create unknown field", "POST", "/tickets", `{"title": "New", "owner": "me"}`, http.StatusBadRequest, nil},
+		{"create invalid status", "POST", "/tickets", `{"title": "New", "status": "later"}`, http.StatusBadRequest, nil},
+		{
+			"update", "PATCH", "/tickets/TK-1", `{"title": "Renamed", "description": "Text", "status": "done"}`,
+			http.StatusOK, &ticketJson{ID: "TK-1", Status: ticket.Done.String(), Title: "Renamed", Description: "Text", Labels: []string{}},
+		},
+		{
+			"update nothing", "PATCH", "/tickets/TK-1", `{"title": "First"}`,
+			http.StatusOK, &ticketJson{ID: "TK-1", Status: ticket.Todo.String(), Title: "First", Labels: []string{}},
+		},
+		{"update empty title", "PATCH", "/tickets/TK-1", `{"title": ""}`, http.StatusBadRequest, nil},
+		{"update missing", "PATCH", "/tickets/TK-9", `{"title": "New"}`, http.StatusNotFound, nil},
+		{
+			"move to status", "POST", "/tickets/TK-1/move", `{"status": "done"}`,
+			http.StatusOK, &ticketJson{ID: "TK-1", Status: ticket.Done.String(), Title: "First", Labels: []string{}},
+		},
+		{
+			"move next", "POST", "/tickets/TK-3/move", `{"direction": "next"}`,
+			http.StatusOK, &ticketJson{ID: "TK-3", Status: ticket.Done.String(), Title: "Third", Labels: []string{}},
+		},
+		{
+			"move previous", "POST", "/tickets/TK-3/move", `{"direction": "previous"}`,
+			http.StatusOK, &ticketJson{ID: "TK-3", Status: ticket.Todo.String(), Title: "Third", Labels: []string{}},
+		},
+		{"move both", "POST", "/tickets/TK-1/move", `{"status": "done", "direction": "next"}`, http.StatusBadRequest, nil},
+		{"move nowhere", "POST", "/tickets/TK-1/move", `{"direction": "up"}`, http.StatusBadRequest, nil},
+		{"rank both", "POST", "/tickets/TK-1/rank", `{"before": "TK-2", "after": "TK-2"}`, http.StatusBadRequest, nil},
+		{"rank itself", "POST", "/tickets/TK-1/rank", `{"before": "TK-1"}`, http.StatusBadRequest, nil},
+		{"rank missing other", "POST", "/tickets/TK-1/rank", `{"after": "TK-9"}`, http.StatusBadRequest, nil},
+		{"delete missing", "DELETE", "/tickets/TK-9", "", http.StatusNotFound, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := newTestServer(t)
+			recorder := request(t, handler, test.method, test.path, test.body)
+			if recorder.Code != test.status {
+				t.Fatalf("%s %s returned %d, want %d: %s", test.method, test.path, recorder.Code, test.status, recorder.Body)
+			}
+			if test.want == nil {
+				return
+			}
+			got := decode[ticketJson](t, recorder)
+			if !sameTicket(got, *test.want) {
+				t.Errorf("%s %s returned %+v, want %+v", test.method, test.path, got, *test.want)
+			}
+		})
+	}
+}
+
+func sameTicket(a, b ticketJson) bool {
+	return a.ID == b.ID && a.Status == b.Status && a.Title == b.Title &&
+		a.Description == b.Description && slices.Equal(a.Labels, b.Labels)
+}
+
+func TestRank(t *testing.T) {
+	tests := []struct {
+		path string
+		body string
+		want []string
+	}{
+		{"/tickets/TK-2/rank", `{"before": "TK-1"}`, []string{"TK-2", "TK-1", "TK-4"}},
+		{"/tickets/TK-1/rank", `{"after": "TK-4"}`, []string{"TK-2", "TK-4", "TK-1"}},
+		{"/tickets/TK-4/rank", `{"after": "TK-1"}`, []string{"TK-1", "TK-4", "TK-2"}},
+		{"/tickets/TK-1/rank", `{"before": "TK-4"}`, []string{"TK-2", "TK-1", "TK-4"}},
+		{"/tickets/TK-1/rank", `{"before": "TK-2"}`, []string{"TK-1", "TK-2", "TK-4"}},
+		{"/tickets/TK-2/rank", `{"after": "TK-1"}`, []string{"TK-1", "TK-2", "TK-4"}},
+	}
+	for _, test := range tests {
+		t.Run(test.path+" "+test.body, func(t *testing.T) {
+			handler := newTestServer(t)
+			if recorder := request(t, handler, "POST", "/tickets", `{"title": "Fourth"}`); recorder.Code != http.StatusCreated {
+				t.Fatalf("creating a ticket returned %d: %s", recorder.Code, recorder.Body)
+			}
+			if recorder := request(t, handler, "POST", test.path, test.body); recorder.Code != http.StatusOK {
+				t.Fatalf("ranking returned %d: %s", recorder.Code, recorder.Body)
+			}
+			if got := ticketIds(t, handler, "todo"); !slices.Equal(got, test.want) {
+				t.Errorf("todo column = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	handler := newTestServer(t)
+	if recorder := request(t, handler, "DELETE", "/tickets/TK-1", ""); recorder.Code != http.StatusNoContent {
+		t.Fatalf("deleting returned %d: %s", recorder.Code, recorder.Body)
+	}
+	if got := ticketIds(t, handler, ""); !slices.Equal(got, []string{"TK-2", "TK-3"}) {
+		t.Errorf("tickets = %v, want TK-1 to be deleted", got)
+	}
+	if recorder := request(t, handler, "GET", "/tickets/TK-1", ""); recorder.Code != http.StatusNotFound {
+		t.Errorf("getting a deleted ticket returned %d, want %d", recorder.Code, http.StatusNotFound)
+	}
+}
+
+func TestWipLimit(t *testing.T) {
+	tests := []struct {
+		enforcement ticket.Enforcement
+		want        int
+	}{
+		{ticket.Warn, http.StatusOK},
+		{ticket.Confirm, http.StatusOK},
+		{ticket.Block, http.StatusConflict},
+	}
+	for _, test := range tests {
+		t.Run(test.enforcement.String(), func(t *testing.T) {
+			ticket.SetWipLimits(ticket.WipLimits{Limits: map[ticket.Status]int{ticket.InProgress: 1}, Enforcement: test.enforcement})
+			t.Cleanup(func() { ticket.SetWipLimits(ticket.WipLimits{}) })
+			handler := newTestServer(t)
+			requests := []struct{ method, path, body string }{
+				{"POST", "/tickets/TK-1/move", `{"status": "in-progress"}`},
+				{"POST", "/tickets/TK-1/move", `{"direction": "next"}`},
+				{"PATCH", "/tickets/TK-1", `{"status": "in-progress"}`},
+			}
+			for _, r := range requests {
+				recorder := request(t, handler, r.method, r.path, r.body)
+				if recorder.Code != test.want {
+					t.Errorf("%s %s %s returned %d, want %d: %s", r.method, r.path, r.body, recorder.Code, test.want, recorder.Body)
+				}
+				if recorder.Code == http.StatusOK {
+					request(t, handler, "POST", "/tickets/TK-1/move", `{"status": "todo"}`)
+				}
+			}
+			if got := ticketIds(t, handler, "in-progress"); test.want == http.StatusConflict && !slices.Equal(got, []string{"TK-3"}) {
+				t.Errorf("in progress column = %v, want the blocked moves to be refused", got)
+			}
+		})
+	}
+}
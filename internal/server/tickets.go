@@ -0,0 +1,274 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/Kavantix/kantui/internal/ticket"
+)
+
+func (s *server) listTickets(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tickets, err := s.load()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var status *ticket.Status
+	if value := r.URL.Query().Get("status"); value != "" {
+		parsed, err := ticket.ParseStatus(value)
+		if err != nil {
+			writeError(w, badRequest("%w", err))
+			return
+		}
+		status = &parsed
+	}
+	result := []ticketJson{}
+	for _, t := range tickets {
+		if status == nil || t.Status == *status {
+			result = append(result, toJson(t))
+		}
+	}
+	writeJson(w, http.StatusOK, result)
+}
+
+func (s *server) getTicket(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, t, err := s.pathTicket(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJson(w, http.StatusOK, toJson(t))
+}
+
+type createRequest struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Status      string   `json:"status"`
+	Labels      []string `json:"labels"`
+}
+
+func (s *server) createTicket(w http.ResponseWriter, r *http.Request) {
+	var body createRequest
+	if err := readJson(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+	newTicket := ticket.Ticket{
+		Title:       ticket.TicketTitle(strings.TrimSpace(body.Title)),
+		Description: ticket.TicketDescription(strings.TrimSpace(body.Description)),
+	}
+	if newTicket.Title == "" {
+		writeError(w, badRequest("title is required"))
+		return
+	}
+	if body.Status != "" {
+		status, err := ticket.ParseStatus(body.Status)
+		if err != nil {
+			writeError(w, badRequest("%w", err))
+			return
+		}
+		newTicket.Status = status
+	}
+	for _, label := range body.Labels {
+		newTicket.Labels = append(newTicket.Labels, ticket.TicketLabel(label))
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	msg, err := ticket.Await(s.store.Import([]ticket.Ticket{newTicket}))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if len(msg.Created) != 1 {
+		writeError(w, httpError{http.StatusInternalServerError, errors.New("created ticket not found")})
+		return
+	}
+	t, err := find(msg.Tickets, msg.Created[0])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJson(w, http.StatusCreated, toJson(t))
+}
+
+type updateRequest struct {
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+	Status      *string `json:"status"`
+}
+
+func (s *server) updateTicket(w http.ResponseWriter, r *http.Request) {
+	var body updateRequest
+	if err := readJson(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, t, err := s.pathTicket(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	title, description, status := t.Title, t.Description, t.Status
+	if body.Status != nil {
+		status, err = ticket.ParseStatus(*body.Status)
+		if err != nil {
+			writeError(w, badRequest("%w", err))
+			return
+		}
+	}
+	if body.Title != nil {
+		title = ticket.TicketTitle(strings.TrimSpace(*body.Title))
+		if title == "" {
+			writeError(w, badRequest("title can not be empty"))
+			return
+		}
+	}
+	if body.Description != nil {
+		description = ticket.TicketDescription(strings.TrimSpace(*body.Description))
+	}
+	if title == t.Title && description == t.Description && status == t.Status {
+		s.apply(w, t.ID, nil)
+		return
+	}
+	s.apply(w, t.ID, s.store.UpdateTicketAndStatus(t.ID, title, description, status))
+}
+
+type moveRequest struct {
+	Direction string `json:"direction"`
+	Status    string `json:"status"`
+}
+
+func (s *server) moveTicket(w http.ResponseWriter, r *http.Request) {
+	var body moveRequest
+	if err := readJson(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, t, err := s.pathTicket(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	switch {
+	case body.Status != "" && body.Direction != "":
+		writeError(w, badRequest("only one of status and direction can be given"))
+	case body.Status != "":
+		status, err := ticket.ParseStatus(body.Status)
+		if err != nil {
+			writeError(w, badRequest("%w", err))
+			return
+		}
+		if status == t.Status {
+			s.apply(w, t.ID, nil)
+			return
+		}
+		s.apply(w, t.ID, s.store.UpdateStatus(t.ID, status))
+	case body.Direction == "next":
+		s.apply(w, t.ID, s.store.MoveToNextStatus(t.ID))
+	case body.Direction == "previous":
+		s.apply(w, t.ID, s.store.MoveToPreviousStatus(t.ID))
+	default:
+		writeError(w, badRequest("expected a status or a direction of next or previous"))
+	}
+}
+
+type rankRequest struct {
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+func (s *server) rankTicket(w http.ResponseWriter, r *http.Request) {
+	var body rankRequest
+	if err := readJson(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+	if (body.Before == "") == (body.After == "") {
+		writeError(w, badRequest("expected exactly one of before and after"))
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tickets, t, err := s.pathTicket(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	otherValue := body.Before + body.After
+	otherId, err := ticket.ParseTicketId(otherValue)
+	if err != nil {
+		writeError(w, badRequest("%w", err))
+		return
+	}
+	other, err := find(tickets, otherId)
+	if err != nil {
+		writeError(w, badRequest("%w", err))
+		return
+	}
+	if other.ID == t.ID {
+		writeError(w, badRequest("can not rank a ticket relative to itself"))
+		return
+	}
+
+	index := slices.IndexFunc(tickets, func(other ticket.Ticket) bool { return other.ID == t.ID })
+	otherIndex := slices.IndexFunc(tickets, func(t ticket.Ticket) bool { return t.ID == other.ID })
+	// The store only ranks tickets towards the other ticket,
+	// so when moving away from it rank relative to its neighbour instead
+	if body.After != "" {
+		switch {
+		case index < otherIndex:
+			s.apply(w, t.ID, s.store.RankTicketAfterTicket(t.ID, other.ID))
+		case index == otherIndex+1:
+			s.apply(w, t.ID, nil)
+		default:
+			s.apply(w, t.ID, s.store.RankTicketBeforeTicket(t.ID, tickets[otherIndex+1].ID))
+		}
+	} else {
+		switch {
+		case index > otherIndex:
+			s.apply(w, t.ID, s.store.RankTicketBeforeTicket(t.ID, other.ID))
+		case index == otherIndex-1:
+			s.apply(w, t.ID, nil)
+		default:
+			s.apply(w, t.ID, s.store.RankTicketAfterTicket(t.ID, tickets[otherIndex-1].ID))
+		}
+	}
+}
+
+func (s *server) deleteTicket(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, t, err := s.pathTicket(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if _, err := ticket.Await(s.store.DeleteTicket(t.ID)); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,133 @@
+// Package statusbar renders the summary of the board below the columns
+package statusbar
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Kavantix/kantui/internal/messages"
+	"github.com/Kavantix/kantui/internal/theme"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Hinter is implemented by modals to show their key hints in the status bar
+type Hinter interface {
+	KeyHints() []key.Binding
+}
+
+type Status struct {
+	Board    string
+	Database string
+	Visible  int
+	Total    int
+	Filter   string
+	// View is the name of the saved view that is shown, if any
+	View string
+	// Swimlanes is the grouping of the swimlanes, empty when there are none
+	Swimlanes string
+	// Query is applied to all columns, empty when all tickets are shown
+	Query string
+	// Selected is the amount of tickets selected for bulk actions
+	Selected int
+	Pending  int
+	// LastError is the error shown in the toast, nil when it was dismissed or retried
+	LastError *messages.ErrorMsg
+	// Keys are the keys typed so far of an unfinished key sequence
+	Keys  string
+	Hints []key.Binding
+}
+
+type styles struct {
+	bar, board, segment, database, pending, error, hint lipgloss.Style
+}
+
+func newStyles() styles {
+	theme := theme.Get()
+	bar := lipgloss.NewStyle().
+		Foreground(theme.StatusBarText).
+		Background(theme.StatusBar)
+	segment := bar.Padding(0, 1)
+	return styles{
+		bar: bar,
+		board: lipgloss.NewStyle().
+			Foreground(theme.AccentText).
+			Background(theme.Accent).
+			Bold(true).
+			Padding(0, 1),
+		segment:  segment,
+		database: segment.Foreground(theme.Muted),
+		pending:  segment.Foreground(theme.Warning),
+		error:    segment.Foreground(theme.Error),
+		hint:     segment.Foreground(theme.Muted),
+	}
+}
+
+// shortenHome replaces the home directory with ~ to leave more room for the rest of the bar
+func shortenHome(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return path
+	}
+	if rest, ok := strings.CutPrefix(path, home); ok && (rest == "" || rest[0] == filepath.Separator) {
+		return "~" + rest
+	}
+	return path
+}
+
+// View renders the status bar as a single line of the given width,
+// the hints are truncated first when there is not enough room
+func View(width int, status Status) string {
+	styles := newStyles()
+	segments := []string{
+		styles.board.Render(status.Board),
+		styles.database.Render(shortenHome(status.Database)),
+	}
+	if status.Visible != status.Total {
+		segments = append(segments, styles.segment.Render(fmt.Sprintf("%d/%d tickets", status.Visible, status.Total)))
+	} else {
+		segments = append(segments, styles.segment.Render(fmt.Sprintf("%d tickets", status.Total)))
+	}
+	if status.View != "" {
+		segments = append(segments, styles.segment.Render("view: "+status.View))
+	}
+	if status.Swimlanes != "" {
+		segments = append(segments, styles.segment.Render("lanes: "+status.Swimlanes))
+	}
+	if status.Query != "" {
+		segments = append(segments, styles.segment.Render("query: "+status.Query))
+	}
+	if status.Filter != "" {
+		segments = append(segments, styles.segment.Render("filter: "+status.Filter))
+	}
+	if status.Selected > 0 {
+		segments = append(segments, styles.pending.Render(fmt.Sprintf("%d selected", status.Selected)))
+	}
+	if status.Pending > 0 {
+		segments = append(segments, styles.pending.Render(fmt.Sprintf("saving %d…", status.Pending)))
+	}
+	if status.LastError != nil {
+		segments = append(segments, styles.error.Render("error: "+status.LastError.FriendlyText))
+	}
+	if status.Keys != "" {
+		segments = append(segments, styles.pending.Render(status.Keys+" …"))
+	}
+	left := ansi.Truncate(strings.Join(segments, ""), width, "…")
+
+	hintsWidth := width - lipgloss.Width(left)
+	hints := ""
+	if hintsWidth > 0 {
+		var rendered []string
+		for _, hint := range status.Hints {
+			if hint.Enabled() {
+				rendered = append(rendered, hint.Help().Key+" "+hint.Help().Desc)
+			}
+		}
+		hints = ansi.Truncate(styles.hint.Render(strings.Join(rendered, " · ")), hintsWidth, "…")
+	}
+	gap := styles.bar.Render(strings.Repeat(" ", max(0, width-lipgloss.Width(left)-lipgloss.Width(hints))))
+	return left + gap + hints
+}
@@ -0,0 +1,474 @@
+// Package table shows all tickets of the board as a sortable and filterable table
+package table
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Kavantix/kantui/internal/column"
+	"github.com/Kavantix/kantui/internal/confirm"
+	"github.com/Kavantix/kantui/internal/keys"
+	"github.com/Kavantix/kantui/internal/label"
+	"github.com/Kavantix/kantui/internal/move"
+	"github.com/Kavantix/kantui/internal/query"
+	"github.com/Kavantix/kantui/internal/theme"
+	"github.com/Kavantix/kantui/internal/ticket"
+	bubblekey "github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	zone "github.com/lrstanley/bubblezone"
+)
+
+type Model struct {
+	store         ticket.Store
+	confirmDelete bool
+
+	// tickets are the tickets given to the table in rank order
+	tickets []ticket.Ticket
+	// rows are the tickets matching the filter in the sort order
+	rows     []ticket.Ticket
+	sort     field
+	reversed bool
+	cursor   int
+	// offset is the first row that is shown
+	offset int
+
+	input  textinput.Model
+	filter query.Query
+	// filtering is set while the filter is being typed
+	filtering bool
+	err       error
+
+	width  int
+	height int
+
+	lastClick *struct {
+		ticketId ticket.TicketId
+		at       time.Time
+	}
+}
+
+func New(store ticket.Store, confirmDelete bool) Model {
+	input := textinput.New()
+	input.Prompt = "/ "
+	input.Placeholder = "label:bug -status:done"
+	return Model{
+		store:         store,
+		confirmDelete: confirmDelete,
+		sort:          status,
+		input:         input,
+	}
+}
+
+// SetTickets shows the tickets, keeping the cursor on the same ticket
+func (m *Model) SetTickets(tickets []ticket.Ticket) {
+	m.tickets = tickets
+	m.updateRows()
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.scroll()
+}
+
+// IsCapturingInput is true while the filter is being typed
+func (m Model) IsCapturingInput() bool {
+	return m.filtering
+}
+
+// Counts returns the amount of tickets matching the filter and the total amount of tickets
+func (m Model) Counts() (visible, total int) {
+	return len(m.rows), len(m.tickets)
+}
+
+// Filter returns the filter that is being typed or applied, empty when the table is not filtered
+func (m Model) Filter() string {
+	if m.filtering {
+		return m.input.Value()
+	}
+	return m.filter.String()
+}
+
+func (m *Model) updateRows() {
+	var selected ticket.TicketId
+	if m.cursor < len(m.rows) {
+		selected = m.rows[m.cursor].ID
+	}
+	m.rows = m.sort.sorted(m.filter.Filter(m.tickets), m.reversed)
+	if index := slices.IndexFunc(m.rows, func(t ticket.Ticket) bool { return t.ID == selected }); index >= 0 {
+		m.cursor = index
+	}
+	m.cursor = max(0, min(m.cursor, len(m.rows)-1))
+	m.scroll()
+}
+
+// ShowTicket moves the cursor to the ticket,
+// the filter is cleared when it hides the ticket
+func (m *Model) ShowTicket(id ticket.TicketId) bool {
+	index := slices.IndexFunc(m.rows, func(t ticket.Ticket) bool { return t.ID == id })
+	if index < 0 && !m.filter.IsEmpty() {
+		m.filter = query.Query{}
+		m.updateRows()
+		index = slices.IndexFunc(m.rows, func(t ticket.Ticket) bool { return t.ID == id })
+	}
+	if index < 0 {
+		return false
+	}
+	m.cursor = index
+	m.scroll()
+	return true
+}
+
+// scroll moves the rows that are shown so the cursor stays visible
+func (m *Model) scroll() {
+	rows := m.visibleRows()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if rows > 0 && m.cursor >= m.offset+rows {
+		m.offset = m.cursor - rows + 1
+	}
+	m.offset = max(0, min(m.offset, len(m.rows)-rows))
+}
+
+func (m *Model) moveCursor(offset int) {
+	m.cursor = max(0, min(m.cursor+offset, len(m.rows)-1))
+	m.scroll()
+}
+
+// Current returns the ticket under the cursor
+func (m Model) Current() (ticket.Ticket, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.rows) {
+		return ticket.Ticket{}, false
+	}
+	return m.rows[m.cursor], true
+}
+
+// neighbourStatus returns the status before or after the status of the ticket
+func neighbourStatus(t ticket.Ticket, offset int) (ticket.Status, bool) {
+	index := slices.Index(ticket.Statusses[:], t.Status) + offset
+	if index < 0 || index >= len(ticket.Statusses) {
+		return t.Status, false
+	}
+	return ticket.Statusses[index], true
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		return m.updateMouse(msg)
+	case tea.KeyMsg:
+		if m.filtering {
+			return m.updateFilter(msg)
+		}
+		keyMap := keys.Get().Column
+		switch {
+		case bubblekey.Matches(msg, keyMap.Up):
+			m.moveCursor(-1)
+		case bubblekey.Matches(msg, keyMap.Down):
+			m.moveCursor(1)
+		case bubblekey.Matches(msg, keyMap.PrevPage):
+			m.moveCursor(-m.visibleRows())
+		case bubblekey.Matches(msg, keyMap.NextPage):
+			m.moveCursor(m.visibleRows())
+		case bubblekey.Matches(msg, keyMap.GoToStart):
+			m.moveCursor(-len(m.rows))
+		case bubblekey.Matches(msg, keyMap.GoToEnd):
+			m.moveCursor(len(m.rows))
+		case bubblekey.Matches(msg, keyMap.Sort):
+			m.sort = (m.sort + 1) % field(numberOfFields)
+			m.reversed = false
+			m.updateRows()
+		case bubblekey.Matches(msg, keys.Get().Table.ReverseSort):
+			m.reversed = !m.reversed
+			m.updateRows()
+		case bubblekey.Matches(msg, keyMap.Filter):
+			m.filtering = true
+			m.input.SetValue(m.filter.String())
+			m.input.CursorEnd()
+			return m, m.input.Focus()
+		case bubblekey.Matches(msg, keyMap.ClearFilter):
+			m.filter = query.Query{}
+			m.err = nil
+			m.updateRows()
+		case bubblekey.Matches(msg, keyMap.Create):
+			return m, ticket.CreateTicket(m.store)
+		default:
+			return m, m.updateTicket(msg)
+		}
+	}
+	return m, nil
+}
+
+// updateTicket handles the actions on the ticket under the cursor
+func (m Model) updateTicket(msg tea.KeyMsg) tea.Cmd {
+	t, ok := m.Current()
+	if !ok {
+		return nil
+	}
+	keyMap := keys.Get().Column
+	targets := []ticket.Ticket{t}
+	switch {
+	case bubblekey.Matches(msg, keyMap.Edit):
+		return ticket.EditTicket(t, m.store)
+	case bubblekey.Matches(msg, keyMap.Delete):
+		deleteTicket := m.store.DeleteTicket(t.ID)
+		if !m.confirmDelete {
+			return deleteTicket
+		}
+		return confirm.Show("Are you sure you want to delete "+ticket.IdStyle().Render(t.ID.String())+"?", deleteTicket)
+	case bubblekey.Matches(msg, keyMap.Archive):
+		return m.store.ArchiveTickets([]ticket.TicketId{t.ID})
+	case bubblekey.Matches(msg, keyMap.Label):
+		return label.Show([]ticket.TicketId{t.ID}, m.store)
+	case bubblekey.Matches(msg, keyMap.Move):
+		return move.Show(targets, m.store, func(status ticket.Status, tickets []ticket.Ticket, move tea.Cmd) tea.Cmd {
+			return column.LimitMove(m.store, status, tickets, move)
+		})
+	case bubblekey.Matches(msg, keyMap.NextStatus):
+		next, ok := neighbourStatus(t, 1)
+		if !ok {
+			return nil
+		}
+		return column.LimitMove(m.store, next, targets, m.store.MoveToNextStatus(t.ID))
+	case bubblekey.Matches(msg, keyMap.PreviousStatus):
+		previous, ok := neighbourStatus(t, -1)
+		if !ok {
+			return nil
+		}
+		return column.LimitMove(m.store, previous, targets, m.store.MoveToPreviousStatus(t.ID))
+	}
+	return nil
+}
+
+// updateFilter applies the filter while it is typed, as long as it is a valid query
+func (m Model) updateFilter(msg tea.KeyMsg) (Model, tea.Cmd) {
+	keyMap := keys.Get().Column
+	switch {
+	case bubblekey.Matches(msg, keyMap.CancelFilter):
+		m.filtering = false
+		m.input.Blur()
+		m.err = nil
+		m.filter = query.Query{}
+		m.updateRows()
+		return m, nil
+	case bubblekey.Matches(msg, keyMap.AcceptFilter):
+		if m.err != nil {
+			return m, nil
+		}
+		m.filtering = false
+		m.input.Blur()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	filter, err := query.Parse(m.input.Value())
+	m.err = err
+	if err == nil {
+		m.filter = filter
+		m.updateRows()
+	}
+	return m, cmd
+}
+
+func (m Model) updateMouse(msg tea.MouseMsg) (Model, tea.Cmd) {
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.moveCursor(-1)
+		return m, nil
+	case tea.MouseButtonWheelDown:
+		m.moveCursor(1)
+		return m, nil
+	case tea.MouseButtonLeft:
+	default:
+		return m, nil
+	}
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+	for f := range field(numberOfFields) {
+		if zone.Get(headerZone(f)).InBounds(msg) {
+			// Clicking the sorted column again reverses the order
+			m.reversed = f == m.sort && !m.reversed
+			m.sort = f
+			m.updateRows()
+			return m, nil
+		}
+	}
+	for i := m.offset; i < min(len(m.rows), m.offset+m.visibleRows()); i++ {
+		if !zone.Get(rowZone(i)).InBounds(msg) {
+			continue
+		}
+		t := m.rows[i]
+		m.cursor = i
+		if m.lastClick != nil && m.lastClick.ticketId == t.ID && time.Since(m.lastClick.at) < 500*time.Millisecond {
+			m.lastClick = nil
+			return m, ticket.EditTicket(t, m.store)
+		}
+		m.lastClick = &struct {
+			ticketId ticket.TicketId
+			at       time.Time
+		}{t.ID, time.Now()}
+		return m, nil
+	}
+	return m, nil
+}
+
+func headerZone(f field) string {
+	return fmt.Sprintf("table-header-%d", f)
+}
+
+func rowZone(index int) string {
+	return fmt.Sprintf("table-row-%d", index)
+}
+
+func style() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Border(theme.Get().FocusedBorderShape).
+		BorderForeground(theme.Get().FocusedBorder)
+}
+
+// chromeHeight are the lines of the table that are not rows:
+// the title, an empty line, the header and the filter
+const chromeHeight = 4
+
+func (m Model) visibleRows() int {
+	_, frameHeight := style().GetFrameSize()
+	return max(0, m.height-frameHeight-chromeHeight)
+}
+
+// fields returns the columns that fit in the width with their widths,
+// the title takes the width that is left
+func (m Model) fields(width int) ([]field, []int) {
+	keyWidth := len("key")
+	for _, t := range m.tickets {
+		keyWidth = max(keyWidth, len(t.ID.String()))
+	}
+	fields := []field{key, title, status}
+	widths := []int{keyWidth, 0, len("IN PROGRESS")}
+	if width >= 70 {
+		fields = append(fields, priority)
+		widths = append(widths, len("priority"))
+	}
+	if width >= 90 {
+		fields = append(fields, labels)
+		widths = append(widths, min(30, width/4))
+	}
+	if width >= 50 {
+		fields = append(fields, age)
+		widths = append(widths, len("age"))
+	}
+	// The cursor marker and a space between the columns
+	used := 2 + len(fields) - 1
+	for _, w := range widths {
+		used += w
+	}
+	widths[1] = max(5, width-used)
+	return fields, widths
+}
+
+func (m Model) cell(t ticket.Ticket, f field, isCursor bool, now time.Time) string {
+	theme := theme.Get()
+	switch f {
+	case key:
+		return ticket.IdStyle().Render(t.ID.String())
+	case title:
+		style := lipgloss.NewStyle().Foreground(theme.Title)
+		if isCursor {
+			style = style.Foreground(theme.Selected).Bold(true)
+		}
+		return style.Render(string(t.Title))
+	case status:
+		color := theme.Accent
+		switch t.Status {
+		case ticket.InProgress:
+			color = theme.InProgress
+		case ticket.Done:
+			color = theme.Done
+		}
+		return lipgloss.NewStyle().Foreground(color).Render(t.Status.ColumnTitle())
+	case priority:
+		return lipgloss.NewStyle().Foreground(theme.Text).Render(t.Priority())
+	case labels:
+		return ticket.LabelStyle().Render(joinLabels(t))
+	default:
+		return lipgloss.NewStyle().Foreground(theme.Muted).Render(formatAge(t.CreatedAt, now))
+	}
+}
+
+// row lays out the cells in their widths, truncating the ones that do not fit
+func row(cells []string, widths []int) string {
+	parts := make([]string, len(cells))
+	for i, cell := range cells {
+		parts[i] = lipgloss.NewStyle().Width(widths[i]).Render(ansi.Truncate(cell, widths[i], "…"))
+	}
+	return strings.Join(parts, " ")
+}
+
+func (m Model) View() string {
+	theme := theme.Get()
+	frameWidth, frameHeight := style().GetFrameSize()
+	width := max(0, m.width-frameWidth)
+	// The filter is shown below the rows
+	height := max(0, m.height-frameHeight-1)
+	fields, widths := m.fields(width)
+
+	arrow := "▲"
+	if m.reversed {
+		arrow = "▼"
+	}
+	header := make([]string, len(fields))
+	for i, f := range fields {
+		name := strings.ToUpper(f.String())
+		style := lipgloss.NewStyle().Foreground(theme.Muted).Bold(true)
+		if f == m.sort {
+			name += " " + arrow
+			style = style.Foreground(theme.Title)
+		}
+		header[i] = zone.Mark(headerZone(f), style.Render(ansi.Truncate(name, widths[i], "…")))
+	}
+
+	now := time.Now()
+	lines := []string{
+		lipgloss.NewStyle().Padding(0, 1).Foreground(theme.AccentText).Background(theme.Accent).Render("ALL TICKETS") +
+			lipgloss.NewStyle().Foreground(theme.Muted).Render(fmt.Sprintf("  %d tickets, sorted by %s", len(m.rows), m.sort)),
+		"",
+		"  " + row(header, widths),
+	}
+	for i := m.offset; i < min(len(m.rows), m.offset+m.visibleRows()); i++ {
+		t := m.rows[i]
+		cells := make([]string, len(fields))
+		for j, f := range fields {
+			cells[j] = m.cell(t, f, i == m.cursor, now)
+		}
+		marker := "  "
+		if i == m.cursor {
+			marker = lipgloss.NewStyle().Foreground(theme.Selected).Render("│ ")
+		}
+		lines = append(lines, zone.Mark(rowZone(i), marker+row(cells, widths)))
+	}
+	if len(m.rows) == 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(theme.Muted).Render("  No tickets"))
+	}
+
+	filter := ""
+	switch {
+	case m.err != nil:
+		filter = m.input.View() + "  " + lipgloss.NewStyle().Foreground(theme.Error).Render(m.err.Error())
+	case m.filtering:
+		filter = m.input.View()
+	case !m.filter.IsEmpty():
+		filter = lipgloss.NewStyle().Foreground(theme.Muted).Render("filter: " + m.filter.String())
+	}
+	content := lipgloss.NewStyle().
+		Width(width).
+		Height(height).
+		MaxHeight(height).
+		Render(strings.Join(lines, "\n"))
+	return style().Render(lipgloss.JoinVertical(lipgloss.Left, content, ansi.Truncate(filter, width, "…")))
+}
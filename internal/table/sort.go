@@ -0,0 +1,106 @@
+package table
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Kavantix/kantui/internal/ticket"
+)
+
+// field is a column of the table, which the tickets can be sorted by
+type field int
+
+const (
+	key field = iota
+	title
+	status
+	priority
+	labels
+	age
+
+	numberOfFields = int(iota)
+)
+
+func (f field) String() string {
+	switch f {
+	case key:
+		return "key"
+	case title:
+		return "title"
+	case status:
+		return "status"
+	case priority:
+		return "priority"
+	case labels:
+		return "labels"
+	default:
+		return "age"
+	}
+}
+
+// sorted returns the tickets ordered by the field, the tickets are given in rank order
+func (f field) sorted(tickets []ticket.Ticket, reversed bool) []ticket.Ticket {
+	return slices.SortedStableFunc(slices.Values(tickets), func(a, b ticket.Ticket) int {
+		var result int
+		switch f {
+		case key:
+			result = cmp.Compare(a.ID.Number(), b.ID.Number())
+		case title:
+			result = strings.Compare(strings.ToLower(string(a.Title)), strings.ToLower(string(b.Title)))
+		case status:
+			result = cmp.Compare(a.Status, b.Status)
+		case priority:
+			result = ticket.ComparePriority(a.Priority(), b.Priority())
+		case labels:
+			labelsA, labelsB := strings.ToLower(joinLabels(a)), strings.ToLower(joinLabels(b))
+			result = strings.Compare(labelsA, labelsB)
+			// Tickets without labels come last
+			if labelsA == "" || labelsB == "" {
+				result = strings.Compare(labelsB, labelsA)
+			}
+		default:
+			// The youngest tickets come first, tickets of unknown age are the oldest
+			result = b.CreatedAt.Compare(a.CreatedAt)
+		}
+		if reversed {
+			return -result
+		}
+		return result
+	})
+}
+
+// joinLabels returns the labels of the ticket except its priority,
+// which has its own column
+func joinLabels(t ticket.Ticket) string {
+	var labels []string
+	for _, label := range t.Labels {
+		if !strings.HasPrefix(strings.ToLower(string(label)), ticket.PriorityPrefix) {
+			labels = append(labels, "#"+string(label))
+		}
+	}
+	return strings.Join(labels, " ")
+}
+
+// formatAge returns the time since the ticket was created in its largest unit,
+// like 5m, 3h or 2d
+func formatAge(created, now time.Time) string {
+	if created.IsZero() {
+		return "–"
+	}
+	age := now.Sub(created)
+	switch {
+	case age < time.Hour:
+		return fmt.Sprintf("%dm", max(0, int(age.Minutes())))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	case age < 14*24*time.Hour:
+		return fmt.Sprintf("%dd", int(age.Hours()/24))
+	case age < 365*24*time.Hour:
+		return fmt.Sprintf("%dw", int(age.Hours()/24/7))
+	default:
+		return fmt.Sprintf("%dy", int(age.Hours()/24/365))
+	}
+}
@@ -0,0 +1,232 @@
+// Package theme holds the colors of the board
+package theme
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+type Theme struct {
+	// Border is the border of the columns
+	Border lipgloss.Color
+	// FocusedBorder is the border of the focused column
+	FocusedBorder lipgloss.Color
+	// FocusedBorderShape makes the focused column stand out without colors
+	FocusedBorderShape lipgloss.Border
+
+	// Title is the title of tickets
+	Title lipgloss.Color
+	// InactiveTitle is the title of tickets in columns that are not focused
+	InactiveTitle lipgloss.Color
+	// Description is the description of tickets
+	Description lipgloss.Color
+	// Selected is the selected ticket of the focused column
+	Selected            lipgloss.Color
+	SelectedDescription lipgloss.Color
+	// InactiveSelected is the selected ticket of the columns that are not focused
+	InactiveSelected            lipgloss.Color
+	InactiveSelectedDescription lipgloss.Color
+
+	// Accent is used for the borders of modals and the todo column
+	Accent lipgloss.Color
+	// AccentText is the text on top of the accent color
+	AccentText lipgloss.Color
+	InProgress lipgloss.Color
+	Done       lipgloss.Color
+
+	// Highlight is used for ticket ids and headings
+	Highlight lipgloss.Color
+	Label     lipgloss.Color
+	// Text is the regular text of modals
+	Text lipgloss.Color
+	// Muted is used for hints and less important text
+	Muted   lipgloss.Color
+	Error   lipgloss.Color
+	Warning lipgloss.Color
+
+	// Dimmed is the board behind a modal
+	Dimmed lipgloss.Color
+	Shadow lipgloss.Color
+
+	StatusBar     lipgloss.Color
+	StatusBarText lipgloss.Color
+}
+
+func Dark() Theme {
+	return Theme{
+		Border:                      "239",
+		FocusedBorder:               "13",
+		FocusedBorderShape:          lipgloss.RoundedBorder(),
+		Title:                       "#dddddd",
+		InactiveTitle:               "248",
+		Description:                 "#777777",
+		Selected:                    "#EE6FF8",
+		SelectedDescription:         "#AD58B4",
+		InactiveSelected:            "33",
+		InactiveSelectedDescription: "68",
+		Accent:                      "62",
+		AccentText:                  "230",
+		InProgress:                  "4",
+		Done:                        "2",
+		Highlight:                   "69",
+		Label:                       "140",
+		Text:                        "252",
+		Muted:                       "245",
+		Error:                       "9",
+		Warning:                     "11",
+		Dimmed:                      "245",
+		Shadow:                      "#333333",
+		StatusBar:                   "236",
+		StatusBarText:               "252",
+	}
+}
+
+func Light() Theme {
+	return Theme{
+		Border:                      "250",
+		FocusedBorder:               "5",
+		FocusedBorderShape:          lipgloss.RoundedBorder(),
+		Title:                       "#1a1a1a",
+		InactiveTitle:               "240",
+		Description:                 "#A49FA5",
+		Selected:                    "#AD58B4",
+		SelectedDescription:         "#C27DC8",
+		InactiveSelected:            "25",
+		InactiveSelectedDescription: "67",
+		Accent:                      "62",
+		AccentText:                  "230",
+		InProgress:                  "4",
+		Done:                        "2",
+		Highlight:                   "26",
+		Label:                       "97",
+		Text:                        "235",
+		Muted:                       "242",
+		Error:                       "160",
+		Warning:                     "130",
+		Dimmed:                      "248",
+		Shadow:                      "#bbbbbb",
+		StatusBar:                   "254",
+		StatusBarText:               "236",
+	}
+}
+
+func HighContrast() Theme {
+	return Theme{
+		Border:                      "7",
+		FocusedBorder:               "11",
+		FocusedBorderShape:          lipgloss.ThickBorder(),
+		Title:                       "15",
+		InactiveTitle:               "15",
+		Description:                 "7",
+		Selected:                    "11",
+		SelectedDescription:         "11",
+		InactiveSelected:            "14",
+		InactiveSelectedDescription: "14",
+		Accent:                      "12",
+		AccentText:                  "0",
+		InProgress:                  "14",
+		Done:                        "10",
+		Highlight:                   "14",
+		Label:                       "13",
+		Text:                        "15",
+		Muted:                       "7",
+		Error:                       "9",
+		Warning:                     "11",
+		Dimmed:                      "8",
+		Shadow:                      "8",
+		StatusBar:                   "0",
+		StatusBarText:               "15",
+	}
+}
+
+var presets = map[string]func() Theme{
+	"dark":          Dark,
+	"light":         Light,
+	"high-contrast": HighContrast,
+}
+
+// Names returns the names of the presets
+func Names() []string {
+	names := []string{"auto"}
+	for name := range presets {
+		names = append(names, name)
+	}
+	slices.Sort(names[1:])
+	return names
+}
+
+// named returns the colors by the names used in the config file
+func (t *Theme) named() map[string]*lipgloss.Color {
+	return map[string]*lipgloss.Color{
+		"border":                        &t.Border,
+		"focused_border":                &t.FocusedBorder,
+		"title":                         &t.Title,
+		"inactive_title":                &t.InactiveTitle,
+		"description":                   &t.Description,
+		"selected":                      &t.Selected,
+		"selected_description":          &t.SelectedDescription,
+		"inactive_selected":             &t.InactiveSelected,
+		"inactive_selected_description": &t.InactiveSelectedDescription,
+		"accent":                        &t.Accent,
+		"accent_text":                   &t.AccentText,
+		"in_progress":                   &t.InProgress,
+		"done":                          &t.Done,
+		"highlight":                     &t.Highlight,
+		"label":                         &t.Label,
+		"text":                          &t.Text,
+		"muted":                         &t.Muted,
+		"error":                         &t.Error,
+		"warning":                       &t.Warning,
+		"dimmed":                        &t.Dimmed,
+		"shadow":                        &t.Shadow,
+		"status_bar":                    &t.StatusBar,
+		"status_bar_text":               &t.StatusBarText,
+	}
+}
+
+// Resolve returns the preset with the given name with the colors overridden.
+// The auto preset picks the dark or light preset based on the background of the terminal,
+// so it must be resolved before the program starts.
+// When colors are disabled with NO_COLOR the high contrast preset is used for its border shapes.
+func Resolve(name string, colors map[string]string) (Theme, error) {
+	if termenv.EnvNoColor() {
+		return HighContrast(), nil
+	}
+	if name == "auto" || name == "" {
+		name = "light"
+		if lipgloss.HasDarkBackground() {
+			name = "dark"
+		}
+	}
+	preset, ok := presets[name]
+	if !ok {
+		return Theme{}, fmt.Errorf("unknown theme %q, available themes: %s", name, strings.Join(Names(), ", "))
+	}
+	theme := preset()
+	named := theme.named()
+	for name, color := range colors {
+		target, ok := named[name]
+		if !ok {
+			return theme, fmt.Errorf("unknown color %q in theme", name)
+		}
+		*target = lipgloss.Color(color)
+	}
+	return theme, nil
+}
+
+var active = Dark()
+
+// Get returns the theme used by all models
+func Get() Theme {
+	return active
+}
+
+// Set replaces the theme used by all models,
+// it should be called before the program starts
+func Set(theme Theme) {
+	active = theme
+}
@@ -0,0 +1,27 @@
+package ticket
+
+import (
+	"strings"
+	"time"
+)
+
+// DuePrefix is the prefix of the labels that give a ticket a due date, like due:2024-05-01
+const DuePrefix = "due:"
+
+// DateFormat is how due dates are written in labels
+const DateFormat = "2006-01-02"
+
+// Due returns the due date of the ticket in the local time zone,
+// false when it has no due label with a valid date
+func (t Ticket) Due() (time.Time, bool) {
+	for _, label := range t.Labels {
+		if len(label) <= len(DuePrefix) || !strings.EqualFold(string(label[:len(DuePrefix)]), DuePrefix) {
+			continue
+		}
+		due, err := time.ParseInLocation(DateFormat, string(label[len(DuePrefix):]), time.Local)
+		if err == nil {
+			return due, true
+		}
+	}
+	return time.Time{}, false
+}
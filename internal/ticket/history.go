@@ -0,0 +1,90 @@
+package ticket
+
+import (
+	"context"
+	"time"
+
+	"github.com/Kavantix/kantui/internal/messages"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// EventKind is the kind of change made to a ticket
+type EventKind string
+
+const (
+	// EventCreated has the status the ticket was created in as value
+	EventCreated EventKind = "created"
+	// EventMoved has the new status as value
+	EventMoved      EventKind = "moved"
+	EventEdited     EventKind = "edited"
+	EventArchived   EventKind = "archived"
+	EventUnarchived EventKind = "unarchived"
+	// EventLabeled has the label that was added as value
+	EventLabeled EventKind = "labeled"
+	// EventUnlabeled has the label that was removed as value
+	EventUnlabeled EventKind = "unlabeled"
+)
+
+// Event is a change made to a ticket, they are recorded by the database
+// so changes made by other processes are part of the history as well
+type Event struct {
+	Kind  EventKind
+	Value string
+	At    time.Time
+}
+
+func (e Event) String() string {
+	var status Status
+	switch e.Kind {
+	case EventCreated:
+		return "created in " + status.Parse(e.Value).ColumnTitle()
+	case EventMoved:
+		return "moved to " + status.Parse(e.Value).ColumnTitle()
+	case EventLabeled:
+		return "added #" + e.Value
+	case EventUnlabeled:
+		return "removed #" + e.Value
+	default:
+		return string(e.Kind)
+	}
+}
+
+// HistoryMsg holds the events of a ticket, oldest first
+type HistoryMsg struct {
+	ID     TicketId
+	Events []Event
+}
+
+func (s *store) History(id TicketId) tea.Cmd {
+	var cmd tea.Cmd
+	cmd = func() tea.Msg {
+		events, err := s.history(id)
+		if err != nil {
+			return messages.ErrorMsg{
+				Err:          err,
+				FriendlyText: "Failed to load ticket history",
+				Retry:        cmd,
+			}
+		}
+		return HistoryMsg{ID: id, Events: events}
+	}
+	return cmd
+}
+
+func (s *store) history(id TicketId) ([]Event, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	rows, err := s.db.GetTicketEvents(context.Background(), id.number)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]Event, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, Event{
+			Kind:  EventKind(row.Kind),
+			Value: row.Value.String,
+			At:    row.CreatedAt,
+		})
+	}
+	return events, nil
+}
@@ -1,11 +1,36 @@
 package ticket
 
 import (
+	"errors"
+	"fmt"
+
+	"github.com/Kavantix/kantui/internal/messages"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 type TicketsUpdatedMsg struct {
 	Tickets []Ticket
+	// Created holds the ids of the tickets added by an import, in the order they were given
+	Created []TicketId
+}
+
+// ArchivedTicketsMsg holds the archived tickets, which are not part of the board
+type ArchivedTicketsMsg struct {
+	Tickets []Ticket
+}
+
+// WatchMsg is the result of polling the database for changes made by other
+// processes, Tickets is only set when Changed is true.
+// Err is set when polling failed, which is retried by the next poll.
+type WatchMsg struct {
+	Changed bool
+	Tickets []Ticket
+	Err     error
+}
+
+// ActivityMsg reports the amount of changes that are still being applied
+type ActivityMsg struct {
+	Pending int
 }
 
 func CreateTicket(store Store) tea.Cmd {
@@ -21,3 +46,29 @@ func EditTicket(ticket Ticket, store Store) tea.Cmd {
 		return model
 	}
 }
+
+// Await synchronously runs a store command outside of the bubbletea runtime,
+// returning the updated tickets or the failure as an error
+func Await(cmd tea.Cmd) (TicketsUpdatedMsg, error) {
+	if cmd == nil {
+		return TicketsUpdatedMsg{}, ErrNothingChanged
+	}
+	switch msg := cmd().(type) {
+	case TicketsUpdatedMsg:
+		return msg, nil
+	case messages.ErrorMsg:
+		return TicketsUpdatedMsg{}, fmt.Errorf("%s: %w", msg.FriendlyText, msg.Err)
+	case messages.CriticalFailureMsg:
+		if msg.FriendlyText == "" {
+			return TicketsUpdatedMsg{}, msg.Err
+		}
+		return TicketsUpdatedMsg{}, fmt.Errorf("%s: %w", msg.FriendlyText, msg.Err)
+	case nil:
+		return TicketsUpdatedMsg{}, ErrNothingChanged
+	default:
+		return TicketsUpdatedMsg{}, fmt.Errorf("unexpected result %T", msg)
+	}
+}
+
+// ErrNothingChanged is returned by Await when the command did not change any tickets
+var ErrNothingChanged = errors.New("nothing changed")
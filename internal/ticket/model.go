@@ -5,8 +5,12 @@ import (
 	"strings"
 
 	"github.com/Kavantix/kantui/internal/confirm"
+	"github.com/Kavantix/kantui/internal/keys"
 	"github.com/Kavantix/kantui/internal/messages"
 	"github.com/Kavantix/kantui/internal/overlay"
+	"github.com/Kavantix/kantui/internal/statusbar"
+	"github.com/Kavantix/kantui/internal/theme"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -26,14 +30,18 @@ type Model struct {
 
 // assert
 var _ overlay.ModalModel = Model{}
+var _ statusbar.Hinter = Model{}
 var _ overlay.Sizeable = Model{}
 
-var idStyle = lipgloss.NewStyle().
-	Foreground(lipgloss.Color("69")).
-	Bold(true)
-
 func IdStyle() lipgloss.Style {
-	return idStyle
+	return lipgloss.NewStyle().
+		Foreground(theme.Get().Highlight).
+		Bold(true)
+}
+
+func LabelStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(theme.Get().Label)
 }
 
 func NewModel(store Store) Model {
@@ -67,7 +75,7 @@ func (m Model) SetSize(width, height int) overlay.ModalModel {
 		width = maxWidth
 	}
 
-	styleWidth, styleHeight := ticketStyle.GetFrameSize()
+	styleWidth, styleHeight := ticketStyle().GetFrameSize()
 	m.width = width
 	m.height = height
 	width -= styleWidth + 2
@@ -96,6 +104,12 @@ func (m Model) Size() (width int, height int) {
 	return m.width, m.height
 }
 
+// KeyHints implements statusbar.Hinter.
+func (m Model) KeyHints() []key.Binding {
+	keyMap := keys.Get().Ticket
+	return []key.Binding{keyMap.Save, keyMap.NextField, keyMap.PreviousField, keyMap.Close}
+}
+
 func (m Model) Init() tea.Cmd {
 	return nil
 }
@@ -120,26 +134,25 @@ func (m Model) hasChanged() bool {
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "esc":
+		keyMap := keys.Get().Ticket
+		switch {
+		case key.Matches(msg, keyMap.Close):
 			if m.hasChanged() {
 				return m, confirm.Show("Are you sure you want to exit editing?", messages.CloseModal)
 			}
 			return m, messages.CloseModal
-		case "ctrl+c":
+		case key.Matches(msg, keyMap.Quit):
 			return m, messages.Quit
-		case "enter", "tab":
-			if m.titleInput.Focused() {
-				m.titleInput.Blur()
-				m.descriptionInput.Focus()
-				return m, nil
-			}
-		case "shift+tab":
+		case key.Matches(msg, keyMap.NextField) && m.titleInput.Focused():
+			m.titleInput.Blur()
+			m.descriptionInput.Focus()
+			return m, nil
+		case key.Matches(msg, keyMap.PreviousField):
 			if m.descriptionInput.Focused() {
 				m.descriptionInput.Blur()
 				m.titleInput.Focus()
 			}
-		case "ctrl+s":
+		case key.Matches(msg, keyMap.Save):
 			if m.store == nil {
 				return m, func() tea.Msg {
 					return messages.CriticalFailureMsg{
@@ -178,7 +191,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m Model) modalTitle() string {
 	titleBuilder := strings.Builder{}
 	if m.ticket.ID.IsValid() {
-		titleBuilder.WriteString(idStyle.Render(m.ticket.ID.String()))
+		titleBuilder.WriteString(IdStyle().Render(m.ticket.ID.String()))
 		titleBuilder.WriteRune(' ')
 	}
 	value := m.titleInput.Value()
@@ -195,10 +208,12 @@ func (m Model) modalTitle() string {
 	return titleBuilder.String()
 }
 
-var ticketStyle = lipgloss.NewStyle().
-	Border(lipgloss.RoundedBorder()).
-	BorderForeground(lipgloss.Color("62")).
-	Padding(1)
+func ticketStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Get().Accent).
+		Padding(1)
+}
 
 func (m Model) View() string {
 	if m.titleInput.Focused() {
@@ -213,7 +228,7 @@ func (m Model) View() string {
 		"Description",
 		m.descriptionInput.View(),
 	)
-	result := ticketStyle.Render(content)
+	result := ticketStyle().Render(content)
 
 	return overlay.Place(4, 0, m.modalTitle(), result, false)
 }
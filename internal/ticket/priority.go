@@ -0,0 +1,92 @@
+package ticket
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PriorityPrefix is the prefix of the labels that give a ticket its priority, like priority:high
+const PriorityPrefix = "priority:"
+
+// priorities orders the common names of priorities from high to low
+var priorities = []string{"critical", "urgent", "highest", "high", "medium", "normal", "low", "lowest"}
+
+// priorityKind is how a priority is written, only priorities of the same kind can be compared
+type priorityKind int
+
+const (
+	// named priorities are ordered by priorities
+	named priorityKind = iota
+	// levels like P0 and P1 are ordered by their number, P0 being the highest
+	level
+	// letters are the priorities of todo.txt, A being the highest
+	letter
+	// other priorities are only ordered alphabetically
+	other
+)
+
+// Priority returns the value of the priority label of the ticket, empty when it has none
+func (t Ticket) Priority() string {
+	for _, label := range t.Labels {
+		if len(label) > len(PriorityPrefix) && strings.EqualFold(string(label[:len(PriorityPrefix)]), PriorityPrefix) {
+			return string(label[len(PriorityPrefix):])
+		}
+	}
+	return ""
+}
+
+// parsePriority returns the kind of the priority and its place within that kind, lower is higher
+func parsePriority(priority string) (priorityKind, int) {
+	lower := strings.ToLower(priority)
+	if index := slices.Index(priorities, lower); index >= 0 {
+		return named, index
+	}
+	if number, ok := strings.CutPrefix(lower, "p"); ok {
+		if value, err := strconv.Atoi(number); err == nil && value >= 0 {
+			return level, value
+		}
+	}
+	if len(lower) == 1 && lower[0] >= 'a' && lower[0] <= 'z' {
+		return letter, int(lower[0] - 'a')
+	}
+	return other, 0
+}
+
+// ComparePriority orders priorities from high to low for sorting: named priorities first,
+// then levels like P1 by their number, letters like A and other priorities alphabetically.
+// It is negative when priority a comes before b, tickets without a priority come last.
+func ComparePriority(a, b string) int {
+	if a == "" || b == "" {
+		return cmp.Compare(b, a)
+	}
+	kindA, placeA := parsePriority(a)
+	kindB, placeB := parsePriority(b)
+	if kindA != kindB {
+		return cmp.Compare(kindA, kindB)
+	}
+	if kindA == other || placeA == placeB {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}
+	return cmp.Compare(placeA, placeB)
+}
+
+// ComparablePriority reports whether the priority is a name like high, a level like P1
+// or a letter like A, which can be compared to priorities of the same kind
+func ComparablePriority(priority string) bool {
+	kind, _ := parsePriority(priority)
+	return kind != other
+}
+
+// ComparePriorityLevel compares two named priorities, two levels like P1 or two letters like A,
+// positive when a is the higher priority.
+// The result is false when the priorities are of a different kind, like high and P1.
+func ComparePriorityLevel(a, b string) (int, bool) {
+	kindA, placeA := parsePriority(a)
+	kindB, placeB := parsePriority(b)
+	if kindA != kindB || kindA == other {
+		return 0, false
+	}
+	return cmp.Compare(placeB, placeA), true
+}
@@ -0,0 +1,101 @@
+package ticket
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestComparePriority(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"critical", "high", -1},
+		{"lowest", "low", 1},
+		{"HIGH", "high", 0},
+		{"P0", "P1", -1},
+		{"p2", "P10", -1},
+		{"P10", "p2", 1},
+		{"A", "b", -1},
+		// Named priorities come before levels, then letters and other priorities
+		{"lowest", "P0", -1},
+		{"P9", "A", -1},
+		{"Z", "someday", -1},
+		{"later", "someday", -1},
+		// Tickets without a priority come last
+		{"", "lowest", 1},
+		{"someday", "", -1},
+		{"", "", 0},
+	}
+	for _, test := range tests {
+		if got := ComparePriority(test.a, test.b); got != test.want {
+			t.Errorf("ComparePriority(%q, %q) = %d, want %d", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestComparePriorityLevel(t *testing.T) {
+	tests := []struct {
+		a, b       string
+		want       int
+		comparable bool
+	}{
+		{"critical", "high", 1, true},
+		{"low", "medium", -1, true},
+		{"P1", "p1", 0, true},
+		{"P0", "P1", 1, true},
+		{"P10", "P2", -1, true},
+		{"A", "C", 1, true},
+		{"high", "P1", 0, false},
+		{"P1", "A", 0, false},
+		{"someday", "someday", 0, false},
+		{"Px", "P1", 0, false},
+	}
+	for _, test := range tests {
+		got, ok := ComparePriorityLevel(test.a, test.b)
+		if got != test.want || ok != test.comparable {
+			t.Errorf("ComparePriorityLevel(%q, %q) = %d, %t, want %d, %t", test.a, test.b, got, ok, test.want, test.comparable)
+		}
+	}
+}
+
+func TestSortByPriority(t *testing.T) {
+	priorities := []string{"", "P10", "someday", "low", "A", "P2", "critical", "P0"}
+	slices.SortFunc(priorities, ComparePriority)
+	want := []string{"critical", "low", "P0", "P2", "P10", "A", "someday", ""}
+	if !slices.Equal(priorities, want) {
+		t.Errorf("sorted priorities = %q, want %q", priorities, want)
+	}
+}
+
+func TestPriorityAndDue(t *testing.T) {
+	tests := []struct {
+		labels   []TicketLabel
+		priority string
+		due      string
+	}{
+		{nil, "", ""},
+		{[]TicketLabel{"bug", "priority:high"}, "high", ""},
+		{[]TicketLabel{"Priority:P1", "DUE:2024-05-01"}, "P1", "2024-05-01"},
+		{[]TicketLabel{"priority:", "due:"}, "", ""},
+		{[]TicketLabel{"due:soon", "due:2024-02-29"}, "", "2024-02-29"},
+	}
+	for _, test := range tests {
+		ticket := Ticket{Labels: test.labels}
+		if got := ticket.Priority(); got != test.priority {
+			t.Errorf("Priority() of %q = %q, want %q", test.labels, got, test.priority)
+		}
+		due, ok := ticket.Due()
+		got := ""
+		if ok {
+			got = due.Format(DateFormat)
+			if due.Location() != time.Local {
+				t.Errorf("Due() of %q is in %s, want the local time zone", test.labels, due.Location())
+			}
+		}
+		if got != test.due {
+			t.Errorf("Due() of %q = %q, want %q", test.labels, got, test.due)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package ticket
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/Kavantix/kantui/internal/database"
+)
+
+func rankedTickets(ranks ...int64) []Ticket {
+	tickets := make([]Ticket, len(ranks))
+	for i, rank := range ranks {
+		tickets[i] = Ticket{ID: TicketId{int64(i + 1)}, rank: rank}
+	}
+	return tickets
+}
+
+func TestComputeNewRank(t *testing.T) {
+	tests := []struct {
+		name                   string
+		ranks                  []int64
+		currentIndex, newIndex int
+		want                   int64
+		wantErr                error
+	}{
+		{"to top", []int64{100, 200, 300}, 2, 0, 100 - rankSpacing, nil},
+		{"to bottom", []int64{100, 200, 300}, 0, 3, 300 + rankSpacing, nil},
+		{"down between", []int64{100, 200, 300}, 0, 2, 250, nil},
+		{"up between", []int64{100, 200, 300}, 2, 1, 150, nil},
+		{"no room down", []int64{100, 200, 201}, 0, 2, 0, errNoRoomBetweenTickets},
+		{"no room up", []int64{100, 101, 300}, 2, 1, 0, errNoRoomBetweenTickets},
+		{"equal ranks", []int64{100, 100, 100}, 2, 1, 0, errNoRoomBetweenTickets},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := computeNewRank(rankedTickets(test.ranks...), test.currentIndex, test.newIndex)
+			if !errors.Is(err, test.wantErr) || got != test.want {
+				t.Errorf("computeNewRank(%v, %d, %d) = %d, %v, want %d, %v", test.ranks, test.currentIndex, test.newIndex, got, err, test.want, test.wantErr)
+			}
+		})
+	}
+
+	for _, indices := range [][2]int{{1, 1}, {-1, 0}, {3, 0}, {0, 4}} {
+		if _, err := computeNewRank(rankedTickets(100, 200, 300), indices[0], indices[1]); err == nil {
+			t.Errorf("computeNewRank with indices %v succeeded", indices)
+		}
+	}
+}
+
+// rankRecorder records the ranks that are written
+type rankRecorder struct {
+	database.Querier
+	ranks map[int64]int64
+}
+
+func (r *rankRecorder) UpdateRank(ctx context.Context, arg database.UpdateRankParams) error {
+	r.ranks[arg.ID] = arg.Rank
+	return nil
+}
+
+func TestRebalanceRanks(t *testing.T) {
+	tickets := rankedTickets(rankSpacing, rankSpacing+1, rankSpacing+1, 3*rankSpacing)
+	recorder := &rankRecorder{ranks: map[int64]int64{}}
+	rebalanced, err := rebalanceRanks(recorder, tickets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, ticket := range rebalanced {
+		if want := int64(i+1) * rankSpacing; ticket.rank != want || ticket.ID != tickets[i].ID {
+			t.Errorf("ticket %d = %s with rank %d, want %s with rank %d", i, ticket.ID, ticket.rank, tickets[i].ID, want)
+		}
+	}
+	// Only the tickets whose rank changed are written
+	want := map[int64]int64{2: 2 * rankSpacing, 3: 3 * rankSpacing, 4: 4 * rankSpacing}
+	if !maps.Equal(recorder.ranks, want) {
+		t.Errorf("written ranks = %v, want %v", recorder.ranks, want)
+	}
+	if tickets[1].rank != rankSpacing+1 {
+		t.Error("rebalanceRanks modified the given tickets")
+	}
+}
+
+func TestRankRebalancesWhenOutOfRoom(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "board.sqlite3")
+	if err := database.Migrate(file, 0); err != nil {
+		t.Fatal(err)
+	}
+	db, err := database.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := NewStore(db)
+	msg, err := Await(store.Import([]Ticket{{Title: "First"}, {Title: "Second"}, {Title: "Third"}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, second, third := msg.Created[0], msg.Created[1], msg.Created[2]
+
+	// Every move halves the room between the first two tickets,
+	// so the ranks run out and have to be rebalanced well before the last move
+	order := []TicketId{first, second, third}
+	for range 40 {
+		moving := order[2]
+		msg, err = Await(store.RankTicketBeforeTicket(moving, order[1]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		order = []TicketId{order[0], moving, order[1]}
+		var got []TicketId
+		for _, ticket := range msg.Tickets {
+			got = append(got, ticket.ID)
+		}
+		if !slices.Equal(got, order) {
+			t.Fatalf("tickets = %v, want %v", got, order)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package ticket
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Kavantix/kantui/internal/database"
+	"github.com/Kavantix/kantui/internal/messages"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SnippetPart is a piece of the text around a search match,
+// Match is set for the terms that matched the query
+type SnippetPart struct {
+	Text  string
+	Match bool
+}
+
+type SearchResult struct {
+	Ticket  Ticket
+	Snippet []SnippetPart
+}
+
+// SearchResultsMsg holds the best matching tickets first
+type SearchResultsMsg struct {
+	Query   string
+	Results []SearchResult
+}
+
+func (s *store) Search(query string, limit int) tea.Cmd {
+	var cmd tea.Cmd
+	cmd = func() tea.Msg {
+		results, err := s.search(query, limit)
+		if err != nil {
+			return messages.ErrorMsg{
+				Err:          err,
+				FriendlyText: "Failed to search tickets",
+				Retry:        cmd,
+			}
+		}
+		return SearchResultsMsg{Query: query, Results: results}
+	}
+	return cmd
+}
+
+func (s *store) search(query string, limit int) ([]SearchResult, error) {
+	match := matchQuery(query)
+	if match == "" {
+		return nil, nil
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	rows, err := s.db.SearchTickets(context.Background(), database.SearchTicketsParams{
+		Query:      match,
+		MaxResults: int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	tickets, err := loadTickets(s.db)
+	if err != nil {
+		return nil, err
+	}
+	byId := make(map[int64]Ticket, len(tickets))
+	for _, ticket := range tickets {
+		byId[ticket.ID.number] = ticket
+	}
+	results := make([]SearchResult, 0, len(rows))
+	for _, row := range rows {
+		ticket, ok := byId[row.TicketID]
+		if !ok {
+			continue
+		}
+		results = append(results, SearchResult{
+			Ticket:  ticket,
+			Snippet: parseSnippet(row.Snippet),
+		})
+	}
+	return results, nil
+}
+
+// matchQuery turns the words that are typed into an fts5 query
+// that matches tickets containing all words, the last word as a prefix
+// so results show up while typing.
+// Every word is quoted so characters like - and : are not read as operators.
+func matchQuery(query string) string {
+	words := strings.Fields(query)
+	for i, word := range words {
+		words[i] = `"` + strings.ReplaceAll(word, `"`, `""`) + `"`
+	}
+	if len(words) > 0 {
+		words[len(words)-1] += "*"
+	}
+	return strings.Join(words, " ")
+}
+
+func parseSnippet(snippet string) []SnippetPart {
+	var parts []SnippetPart
+	for {
+		start := strings.Index(snippet, database.SnippetStart)
+		if start < 0 {
+			break
+		}
+		end := strings.Index(snippet[start:], database.SnippetEnd)
+		if end < 0 {
+			break
+		}
+		end += start
+		if start > 0 {
+			parts = append(parts, SnippetPart{Text: snippet[:start]})
+		}
+		parts = append(parts, SnippetPart{Text: snippet[start+len(database.SnippetStart) : end], Match: true})
+		snippet = snippet[end+len(database.SnippetEnd):]
+	}
+	if snippet != "" {
+		parts = append(parts, SnippetPart{Text: snippet})
+	}
+	return parts
+}
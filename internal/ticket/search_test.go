@@ -0,0 +1,50 @@
+package ticket
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Kavantix/kantui/internal/database"
+)
+
+func TestMatchQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", ""},
+		{"   ", ""},
+		{"login", `"login"*`},
+		{" login  page ", `"login" "page"*`},
+		{"TK-12", `"TK-12"*`},
+		{"title:login OR", `"title:login" "OR"*`},
+		{`say "hi"`, `"say" """hi"""*`},
+	}
+	for _, test := range tests {
+		if got := matchQuery(test.query); got != test.want {
+			t.Errorf("matchQuery(%q) = %s, want %s", test.query, got, test.want)
+		}
+	}
+}
+
+func TestParseSnippet(t *testing.T) {
+	start, end := database.SnippetStart, database.SnippetEnd
+	tests := []struct {
+		snippet string
+		want    []SnippetPart
+	}{
+		{"", nil},
+		{"no match", []SnippetPart{{Text: "no match"}}},
+		{start + "login" + end, []SnippetPart{{Text: "login", Match: true}}},
+		{
+			"fix " + start + "login" + end + " on " + start + "page" + end + "…",
+			[]SnippetPart{{Text: "fix "}, {Text: "login", Match: true}, {Text: " on "}, {Text: "page", Match: true}, {Text: "…"}},
+		},
+		{"broken " + start + "match", []SnippetPart{{Text: "broken " + start + "match"}}},
+	}
+	for _, test := range tests {
+		if got := parseSnippet(test.snippet); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("parseSnippet(%q) = %+v, want %+v", test.snippet, got, test.want)
+		}
+	}
+}
@@ -5,7 +5,13 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"log/slog"
 	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/Kavantix/kantui/internal/database"
 	"github.com/Kavantix/kantui/internal/messages"
@@ -23,6 +29,11 @@ func (i TicketId) IsValid() bool {
 	return i.number > 0
 }
 
+// Number is the number in the key of the ticket, which increases with every new ticket
+func (i TicketId) Number() int64 {
+	return i.number
+}
+
 func (i TicketId) String() string {
 	if i.number <= 0 {
 		return "INVALID"
@@ -30,6 +41,15 @@ func (i TicketId) String() string {
 	return fmt.Sprintf("TK-%d", i.number)
 }
 
+// ParseTicketId parses both the TK-1 notation and a bare ticket number
+func ParseTicketId(value string) (TicketId, error) {
+	number, err := strconv.ParseInt(strings.TrimPrefix(strings.ToUpper(value), "TK-"), 10, 64)
+	if err != nil || number <= 0 {
+		return TicketId{}, fmt.Errorf("invalid ticket id %q", value)
+	}
+	return TicketId{number}, nil
+}
+
 const (
 	Todo Status = iota
 	InProgress
@@ -57,8 +77,21 @@ func (s Status) ColumnTitle() string {
 	}
 }
 
+// ParseStatus parses a status by either its name or its column title,
+// separators are ignored so in-progress and in_progress are accepted as well
+func ParseStatus(value string) (Status, error) {
+	normalize := strings.NewReplacer("-", "", "_", "", " ", "").Replace
+	for _, status := range Statusses {
+		if strings.EqualFold(normalize(value), status.String()) || strings.EqualFold(normalize(value), normalize(status.ColumnTitle())) {
+			return status, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid status %q", value)
+}
+
 type TicketTitle string
 type TicketDescription string
+type TicketLabel string
 
 type Ticket struct {
 	ID          TicketId
@@ -66,241 +99,507 @@ type Ticket struct {
 	Status      Status
 	Title       TicketTitle
 	Description TicketDescription
+	Labels      []TicketLabel
+	// CreatedAt is zero for tickets that were created before it was recorded
+	CreatedAt time.Time
+}
+
+// Position is where a ticket is placed within a column
+type Position struct {
+	kind   positionKind
+	ticket TicketId
+}
+
+type positionKind int
+
+const (
+	top positionKind = iota
+	bottom
+	after
+	before
+	inPlace
+)
+
+// AtTop places a ticket above all other tickets of the column
+func AtTop() Position {
+	return Position{kind: top}
+}
+
+// AtBottom places a ticket below all other tickets of the column
+func AtBottom() Position {
+	return Position{kind: bottom}
+}
+
+// AfterTicket places a ticket directly after another ticket of the column
+func AfterTicket(id TicketId) Position {
+	return Position{kind: after, ticket: id}
+}
+
+// BeforeTicket places a ticket directly before another ticket of the column
+func BeforeTicket(id TicketId) Position {
+	return Position{kind: before, ticket: id}
 }
 
+// InPlace keeps the rank of a ticket, like moving it to the next or previous status does,
+// so it is placed among the tickets of the column by where it was on the board
+func InPlace() Position {
+	return Position{kind: inPlace}
+}
+
+// Store persists tickets to the database.
+//
+// The commands returned by the store can safely run concurrently,
+// changes are serialized and applied in a transaction against the committed
+// state of the database, after which the resulting tickets are reloaded.
 type Store interface {
 	Load() tea.Msg
 	New(title TicketTitle, description TicketDescription) tea.Cmd
 	UpdateTicket(id TicketId, newTitle TicketTitle, newDescription TicketDescription) tea.Cmd
 	UpdateStatus(id TicketId, newStatus Status) tea.Cmd
+	// UpdateTicketAndStatus changes the content and the status of a ticket in a single transaction
+	UpdateTicketAndStatus(id TicketId, newTitle TicketTitle, newDescription TicketDescription, newStatus Status) tea.Cmd
 	RankTicketAfterTicket(id, afterId TicketId) tea.Cmd
 	RankTicketBeforeTicket(id, beforeId TicketId) tea.Cmd
 	MoveToNextStatus(id TicketId) tea.Cmd
 	MoveToPreviousStatus(id TicketId) tea.Cmd
+	// MoveTicket changes the status and rank of a ticket in a single transaction
+	MoveTicket(id TicketId, status Status, position Position) tea.Cmd
+	// MoveTickets moves all tickets to the position keeping their order
+	MoveTickets(ids []TicketId, status Status, position Position) tea.Cmd
 	DeleteTicket(id TicketId) tea.Cmd
+	DeleteTickets(ids []TicketId) tea.Cmd
+	// ArchiveTickets hides the tickets from the board without deleting them
+	ArchiveTickets(ids []TicketId) tea.Cmd
+	// LoadArchived reads the archived tickets, the most recently archived first,
+	// resulting in an ArchivedTicketsMsg
+	LoadArchived() tea.Msg
+	// UnarchiveTickets shows archived tickets on the board again, in the column they were archived from
+	UnarchiveTickets(ids []TicketId) tea.Cmd
+	AddLabel(ids []TicketId, label TicketLabel) tea.Cmd
+	RemoveLabel(ids []TicketId, label TicketLabel) tea.Cmd
+	// Search finds the tickets matching all words of the query in their key,
+	// title, description or labels, resulting in a SearchResultsMsg
+	Search(query string, limit int) tea.Cmd
+	// History loads the changes made to the ticket, resulting in a HistoryMsg
+	History(id TicketId) tea.Cmd
+	// Import adds all tickets in a single transaction, ranking them after the
+	// existing tickets in the order they are given.
+	// The ids of the new tickets are in the Created field of the TicketsUpdatedMsg.
+	Import(tickets []Ticket) tea.Cmd
+	// Watch waits for the next poll of the database for changes made by other
+	// processes, resulting in a WatchMsg
+	Watch() tea.Cmd
+	// WaitForActivity waits until a change starts or finishes,
+	// resulting in an ActivityMsg
+	WaitForActivity() tea.Cmd
+	// CheckWipLimit checks the limit of the status against the committed tickets,
+	// returning a WipLimitError when moving the tickets would exceed it
+	CheckWipLimit(ids []TicketId, status Status) error
+	// Write runs a change to the database that is not made through the store, like saving a view,
+	// in a transaction so Watch does not report it as a change made by another process
+	Write(change func(tx database.Querier) error) error
 }
 
+const watchInterval = time.Second
+
 type store struct {
-	tickets []Ticket
-	db      database.Connection
+	// mutex serializes all access to the database and dataVersion
+	mutex       sync.Mutex
+	db          database.Connection
+	dataVersion int64
+
+	pending  atomic.Int64
+	activity chan struct{}
 }
 
 func NewStore(db database.Connection) Store {
 	s := &store{
-		db: db,
+		db:       db,
+		activity: make(chan struct{}, 1),
 	}
 	return s
 }
 
+func (s *store) WaitForActivity() tea.Cmd {
+	return func() tea.Msg {
+		<-s.activity
+		return ActivityMsg{Pending: int(s.pending.Load())}
+	}
+}
+
+// track counts the change as pending until done is called
+func (s *store) track() (done func()) {
+	s.pending.Add(1)
+	s.notifyActivity()
+	return func() {
+		s.pending.Add(-1)
+		s.notifyActivity()
+	}
+}
+
+func (s *store) notifyActivity() {
+	select {
+	case s.activity <- struct{}{}:
+	default:
+		// A notification is already waiting to be received
+	}
+}
+
 func (s *store) Load() tea.Msg {
-	tickets, err := s.db.GetTickets(context.Background())
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	tickets, err := s.load()
 	if err != nil {
-		return messages.CriticalFailureMsg{
+		return messages.ErrorMsg{
 			Err:          err,
 			FriendlyText: "Failed to load tickets",
+			Retry:        s.Load,
 		}
 	}
+	return TicketsUpdatedMsg{Tickets: tickets}
+}
+
+// load reads all tickets, the caller should hold the mutex
+func (s *store) load() ([]Ticket, error) {
+	// Read the version before the tickets so changes made while loading
+	// are picked up by the next watch
+	dataVersion, err := s.db.DataVersion(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	s.dataVersion = dataVersion
+	return loadTickets(s.db)
+}
+
+func loadTickets(db database.Querier) ([]Ticket, error) {
+	rows, err := db.GetTickets(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return withLabels(db, rows)
+}
+
+// withLabels turns the rows into tickets with their labels
+func withLabels(db database.Querier, rows []database.Ticket) ([]Ticket, error) {
+	labels, err := db.GetTicketLabels(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ticket labels: %w", err)
+	}
+	labelsByTicket := map[int64][]TicketLabel{}
+	for _, label := range labels {
+		labelsByTicket[label.TicketID] = append(labelsByTicket[label.TicketID], TicketLabel(label.Label))
+	}
 	var status Status
-	for _, ticket := range tickets {
-		s.tickets = append(s.tickets,
+	tickets := make([]Ticket, 0, len(rows))
+	for _, ticket := range rows {
+		tickets = append(tickets,
 			Ticket{
 				ID:          TicketId{ticket.ID},
 				Status:      status.Parse(ticket.Status),
 				rank:        ticket.Rank,
 				Title:       TicketTitle(ticket.Title),
 				Description: TicketDescription(ticket.Description.String),
+				Labels:      labelsByTicket[ticket.ID],
+				CreatedAt:   ticket.CreatedAt.Time,
 			},
 		)
 	}
-	return TicketsUpdatedMsg{Tickets: s.tickets}
+	return tickets, nil
+}
+
+// mutate returns a command that serializes a change to the database.
+// The change runs in a transaction and receives the committed tickets,
+// returning ErrNothingChanged results in a nil message.
+// When the change fails the transaction is rolled back, so the loaded tickets
+// still match the database, and an ErrorMsg is returned that can retry it.
+func (s *store) mutate(friendlyText string, change func(tx database.Querier, tickets []Ticket) error) tea.Cmd {
+	var cmd tea.Cmd
+	cmd = func() tea.Msg {
+		done := s.track()
+		defer done()
+		tickets, err := s.runMutation(change)
+		if errors.Is(err, ErrNothingChanged) {
+			return nil
+		}
+		if err != nil {
+			return messages.ErrorMsg{
+				Err:          err,
+				FriendlyText: friendlyText,
+				Retry:        cmd,
+			}
+		}
+		return TicketsUpdatedMsg{Tickets: tickets}
+	}
+	return cmd
+}
+
+func (s *store) runMutation(change func(tx database.Querier, tickets []Ticket) error) ([]Ticket, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tx, err := s.db.BeginTransaction()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	tickets, err := loadTickets(tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := change(tx, tickets); err != nil {
+		return nil, err
+	}
+	tickets, err = loadTickets(tx)
+	if err != nil {
+		return nil, err
+	}
+	// The commit does not change the data version of the connection,
+	// so Watch only reloads the tickets for changes made by others
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
+func (s *store) CheckWipLimit(ids []TicketId, status Status) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	// The data version is left alone so Watch still picks up the changes of others
+	tickets, err := loadTickets(s.db)
+	if err != nil {
+		return err
+	}
+	return wipLimits.Check(tickets, ids, status)
+}
+
+func (s *store) Write(change func(tx database.Querier) error) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	tx, err := s.db.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := change(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *store) Watch() tea.Cmd {
+	return tea.Tick(watchInterval, func(time.Time) tea.Msg {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		dataVersion, err := s.db.DataVersion(context.Background())
+		if err != nil {
+			return WatchMsg{Err: err}
+		}
+		if dataVersion == s.dataVersion {
+			return WatchMsg{}
+		}
+		tickets, err := s.load()
+		if err != nil {
+			return WatchMsg{Err: err}
+		}
+		return WatchMsg{Changed: true, Tickets: tickets}
+	})
 }
 
 func (s *store) New(title TicketTitle, description TicketDescription) tea.Cmd {
-	return func() tea.Msg {
-		row, err := s.db.AddTicket(context.Background(), database.AddTicketParams{
+	return s.mutate("Failed to write new ticket to db", func(tx database.Querier, _ []Ticket) error {
+		_, err := tx.AddTicket(context.Background(), database.AddTicketParams{
 			Title: string(title),
 			Description: sql.NullString{
 				String: string(description),
 				Valid:  description != "",
 			},
 		})
-		if err != nil {
-			return messages.CriticalFailureMsg{
-				Err:          err,
-				FriendlyText: "Failed to write new ticket to db",
-			}
-		}
-		s.tickets = append(s.tickets,
-			Ticket{
-				ID:          TicketId{row.ID},
-				rank:        row.Rank,
-				Title:       title,
-				Description: description,
-			},
-		)
-		return TicketsUpdatedMsg{s.tickets}
-	}
+		return err
+	})
 }
 
 func (s *store) UpdateTicket(id TicketId, newTitle TicketTitle, newDescription TicketDescription) tea.Cmd {
-	return func() tea.Msg {
-		for i, t := range s.tickets {
-			if t.ID == id {
-				err := s.db.UpdateTicketContent(context.Background(), database.UpdateTicketContentParams{
-					ID:    id.number,
-					Title: string(newTitle),
-					Description: sql.NullString{
-						String: string(newDescription),
-						Valid:  newDescription != "",
-					},
-				})
-				if err != nil {
-					return messages.CriticalFailureMsg{
-						Err:          err,
-						FriendlyText: "Failed to update ticket",
-					}
-				}
-				s.tickets[i].Title = newTitle
-				s.tickets[i].Description = newDescription
-			}
-		}
-		return TicketsUpdatedMsg{s.tickets}
-	}
-
+	return s.mutate("Failed to update ticket", func(tx database.Querier, _ []Ticket) error {
+		return tx.UpdateTicketContent(context.Background(), database.UpdateTicketContentParams{
+			ID:    id.number,
+			Title: string(newTitle),
+			Description: sql.NullString{
+				String: string(newDescription),
+				Valid:  newDescription != "",
+			},
+		})
+	})
 }
 
 func (s *store) UpdateStatus(id TicketId, newStatus Status) tea.Cmd {
-	return func() tea.Msg {
-		for i, t := range s.tickets {
-			if t.ID == id {
-				err := s.db.UpdateStatus(context.Background(), database.UpdateStatusParams{
-					ID:     id.number,
-					Status: newStatus.String(),
-				})
-				if err != nil {
-					return messages.CriticalFailureMsg{
-						Err:          err,
-						FriendlyText: "Failed to update ticket status",
-					}
-				}
-				s.tickets[i].Status = newStatus
-			}
+	return s.mutate("Failed to update ticket status", func(tx database.Querier, tickets []Ticket) error {
+		if err := enforceWipLimit(tickets, []TicketId{id}, newStatus); err != nil {
+			return err
 		}
-		return TicketsUpdatedMsg{s.tickets}
-	}
-
+		return tx.UpdateStatus(context.Background(), database.UpdateStatusParams{
+			ID:     id.number,
+			Status: newStatus.String(),
+		})
+	})
 }
 
-func (s *store) RankTicketAfterTicket(id, afterId TicketId) tea.Cmd {
-	return func() tea.Msg {
-		index := s.indexOfTicket(id)
-		afterIndex := s.indexOfTicket(afterId)
-		if afterIndex < 0 || index < 0 {
-			return nil
+func (s *store) UpdateTicketAndStatus(id TicketId, newTitle TicketTitle, newDescription TicketDescription, newStatus Status) tea.Cmd {
+	return s.mutate("Failed to update ticket", func(tx database.Querier, tickets []Ticket) error {
+		index := indexOfTicket(tickets, id)
+		if index < 0 {
+			return fmt.Errorf("ticket %s not found", id)
 		}
-		if index >= afterIndex {
-			return messages.CriticalFailureMsg{
-				Err: fmt.Errorf("Ranking after should only be used when ticket is not already after other ticket"),
+		current := tickets[index]
+		changed := false
+		if current.Title != newTitle || current.Description != newDescription {
+			err := tx.UpdateTicketContent(context.Background(), database.UpdateTicketContentParams{
+				ID:    id.number,
+				Title: string(newTitle),
+				Description: sql.NullString{
+					String: string(newDescription),
+					Valid:  newDescription != "",
+				},
+			})
+			if err != nil {
+				return err
 			}
+			changed = true
 		}
-		return s.rankTicket(index, afterIndex+1)
-	}
+		if current.Status != newStatus {
+			if err := enforceWipLimit(tickets, []TicketId{id}, newStatus); err != nil {
+				return err
+			}
+			err := tx.UpdateStatus(context.Background(), database.UpdateStatusParams{
+				ID:     id.number,
+				Status: newStatus.String(),
+			})
+			if err != nil {
+				return err
+			}
+			changed = true
+		}
+		if !changed {
+			return ErrNothingChanged
+		}
+		return nil
+	})
 }
 
-func (s *store) RankTicketBeforeTicket(id, beforeId TicketId) tea.Cmd {
-	return func() tea.Msg {
-		index := s.indexOfTicket(id)
-		beforeIndex := s.indexOfTicket(beforeId)
-		if beforeIndex < 0 || index < 0 {
-			return nil
+// RankTicketAfterTicket ranks the ticket directly after the other ticket,
+// nothing changes when it is already ranked after it
+func (s *store) RankTicketAfterTicket(id, afterId TicketId) tea.Cmd {
+	return s.mutate("Failed to update rank", func(tx database.Querier, tickets []Ticket) error {
+		index := indexOfTicket(tickets, id)
+		afterIndex := indexOfTicket(tickets, afterId)
+		if afterIndex < 0 || index < 0 || index >= afterIndex {
+			return ErrNothingChanged
 		}
-		if index <= beforeIndex {
-			return messages.CriticalFailureMsg{
-				Err: fmt.Errorf("Ranking before should only be used when ticket is not already before other ticket"),
-			}
+		return rankTicket(tx, tickets, index, afterIndex+1)
+	})
+}
+
+// RankTicketBeforeTicket ranks the ticket directly before the other ticket,
+// nothing changes when it is already ranked before it
+func (s *store) RankTicketBeforeTicket(id, beforeId TicketId) tea.Cmd {
+	return s.mutate("Failed to update rank", func(tx database.Querier, tickets []Ticket) error {
+		index := indexOfTicket(tickets, id)
+		beforeIndex := indexOfTicket(tickets, beforeId)
+		if beforeIndex < 0 || index < 0 || index <= beforeIndex {
+			return ErrNothingChanged
 		}
-		return s.rankTicket(index, beforeIndex)
-	}
+		return rankTicket(tx, tickets, index, beforeIndex)
+	})
 }
 
-func (s *store) indexOfTicket(id TicketId) int {
-	return slices.IndexFunc(s.tickets, func(ticket Ticket) bool { return ticket.ID == id })
+func indexOfTicket(tickets []Ticket, id TicketId) int {
+	return slices.IndexFunc(tickets, func(ticket Ticket) bool { return ticket.ID == id })
 }
 
-func (s *store) rankTicket(currentIndex, newIndex int) tea.Msg {
+func rankTicket(tx database.Querier, tickets []Ticket, currentIndex, newIndex int) error {
 	if currentIndex == newIndex {
-		return nil
+		return ErrNothingChanged
 	}
 
-	newRank, err := s.computeNewRank(currentIndex, newIndex)
-	if err != nil {
-		return messages.CriticalFailureMsg{
-			Err:          err,
-			FriendlyText: "Failed to update rank",
+	newRank, err := computeNewRank(tickets, currentIndex, newIndex)
+	if errors.Is(err, errNoRoomBetweenTickets) {
+		tickets, err = rebalanceRanks(tx, tickets)
+		if err != nil {
+			return err
 		}
+		newRank, err = computeNewRank(tickets, currentIndex, newIndex)
+	}
+	if err != nil {
+		return err
 	}
 
-	ticket := s.tickets[currentIndex]
-
-	// Update database
-	err = s.db.UpdateRank(context.Background(), database.UpdateRankParams{
-		ID:   ticket.ID.number,
+	return tx.UpdateRank(context.Background(), database.UpdateRankParams{
+		ID:   tickets[currentIndex].ID.number,
 		Rank: newRank,
 	})
-	if err != nil {
-		return messages.CriticalFailureMsg{
-			Err:          err,
-			FriendlyText: "Failed to update rank",
-		}
-	}
+}
 
-	// Update loaded tickets
-	ticket.rank = newRank
-	if newIndex > currentIndex {
-		s.tickets = slices.Insert(s.tickets, newIndex, ticket)
-		s.tickets = slices.Delete(s.tickets, currentIndex, currentIndex+1)
-	} else {
-		s.tickets = slices.Delete(s.tickets, currentIndex, currentIndex+1)
-		s.tickets = slices.Insert(s.tickets, newIndex, ticket)
-	}
+const rankSpacing = 1_000_000
+
+var errNoRoomBetweenTickets = errors.New("ran out of room between tickets")
 
-	return TicketsUpdatedMsg{s.tickets}
+// rebalanceRanks spreads the ranks of all tickets evenly while keeping their
+// order, returning the tickets with their new ranks
+func rebalanceRanks(tx database.Querier, tickets []Ticket) ([]Ticket, error) {
+	slog.Info("Rebalancing ticket ranks", slog.Int("tickets", len(tickets)))
+	tickets = slices.Clone(tickets)
+	for i := range tickets {
+		rank := int64(i+1) * rankSpacing
+		if tickets[i].rank == rank {
+			continue
+		}
+		err := tx.UpdateRank(context.Background(), database.UpdateRankParams{
+			ID:   tickets[i].ID.number,
+			Rank: rank,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to rebalance ranks: %w", err)
+		}
+		tickets[i].rank = rank
+	}
+	return tickets, nil
 }
 
-func (s *store) computeNewRank(currentIndex, newIndex int) (int64, error) {
+func computeNewRank(tickets []Ticket, currentIndex, newIndex int) (int64, error) {
 	if currentIndex == newIndex {
 		return 0, errors.New("current and new index are the same")
 	}
-	if currentIndex < 0 || currentIndex >= len(s.tickets) || newIndex < 0 || newIndex > len(s.tickets) {
+	if currentIndex < 0 || currentIndex >= len(tickets) || newIndex < 0 || newIndex > len(tickets) {
 		return 0, fmt.Errorf("invalid indices: currentIndex=%d, newIndex=%d", currentIndex, newIndex)
 	}
 
 	// Determine the ticket to compare to
 	var comparisonTicket Ticket
 	if newIndex > currentIndex {
-		comparisonTicket = s.tickets[newIndex-1]
+		comparisonTicket = tickets[newIndex-1]
 	} else {
-		comparisonTicket = s.tickets[newIndex]
+		comparisonTicket = tickets[newIndex]
 	}
 
 	// Compute new rank
 	var newRank int64
 	if newIndex == 0 {
-		newRank = comparisonTicket.rank - 1_000_000
-	} else if newIndex >= len(s.tickets) {
-		newRank = comparisonTicket.rank + 1_000_000
+		newRank = comparisonTicket.rank - rankSpacing
+	} else if newIndex >= len(tickets) {
+		newRank = comparisonTicket.rank + rankSpacing
 	} else {
 		var gap int64
 		if newIndex > currentIndex {
-			gap = s.tickets[newIndex].rank - comparisonTicket.rank
+			gap = tickets[newIndex].rank - comparisonTicket.rank
 			newRank = comparisonTicket.rank + gap/2
 		} else {
-			gap = comparisonTicket.rank - s.tickets[newIndex-1].rank
+			gap = comparisonTicket.rank - tickets[newIndex-1].rank
 			newRank = comparisonTicket.rank - gap/2
 		}
 
 		if gap <= 1 {
-			return 0, fmt.Errorf("ran out of room between tickets")
+			return 0, errNoRoomBetweenTickets
 		}
 	}
 
@@ -308,73 +607,315 @@ func (s *store) computeNewRank(currentIndex, newIndex int) (int64, error) {
 }
 
 func (s *store) MoveToPreviousStatus(id TicketId) tea.Cmd {
-	var ticket *Ticket
-	for i, t := range s.tickets {
-		if t.ID == id {
-			ticket = &s.tickets[i]
+	return s.moveStatus(id, func(status Status) (Status, bool) {
+		switch status {
+		case Todo:
+			return status, false
+		case InProgress:
+			return Todo, true
+		case Done:
+			return InProgress, true
+		default:
+			// assert amount of statusses didnt change
+			var _ = [3]any{}[NumberOfStatusses-1]
+			panic("unreachable")
+		}
+	})
+}
+
+func (s *store) MoveToNextStatus(id TicketId) tea.Cmd {
+	return s.moveStatus(id, func(status Status) (Status, bool) {
+		switch status {
+		case Todo:
+			return InProgress, true
+		case InProgress:
+			return Done, true
+		case Done:
+			return status, false
+		default:
+			// assert amount of statusses didnt change
+			var _ = [3]any{}[NumberOfStatusses-1]
+			panic("unreachable")
+		}
+	})
+}
+
+// moveStatus moves the ticket based on its committed status,
+// so repeated moves are applied on top of each other
+func (s *store) moveStatus(id TicketId, newStatus func(Status) (Status, bool)) tea.Cmd {
+	return s.mutate("Failed to update ticket status", func(tx database.Querier, tickets []Ticket) error {
+		index := indexOfTicket(tickets, id)
+		if index < 0 {
+			return ErrNothingChanged
+		}
+		status, ok := newStatus(tickets[index].Status)
+		if !ok {
+			return ErrNothingChanged
+		}
+		if err := enforceWipLimit(tickets, []TicketId{id}, status); err != nil {
+			return err
+		}
+		return tx.UpdateStatus(context.Background(), database.UpdateStatusParams{
+			ID:     id.number,
+			Status: status.String(),
+		})
+	})
+}
+
+func (s *store) MoveTicket(id TicketId, status Status, position Position) tea.Cmd {
+	return s.MoveTickets([]TicketId{id}, status, position)
+}
+
+func (s *store) MoveTickets(ids []TicketId, status Status, position Position) tea.Cmd {
+	return s.mutate("Failed to move tickets", func(tx database.Querier, tickets []Ticket) error {
+		if err := enforceWipLimit(tickets, ids, status); err != nil {
+			return err
+		}
+		ordered := inRankOrder(tickets, ids)
+		changed := false
+		for i, id := range ordered {
+			position := position
+			if i > 0 && position.kind != inPlace {
+				// The following tickets keep their order below the first one
+				position = AfterTicket(ordered[i-1])
+				var err error
+				tickets, err = loadTickets(tx)
+				if err != nil {
+					return err
+				}
+			}
+			err := moveTicket(tx, tickets, id, status, position)
+			if errors.Is(err, ErrNothingChanged) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			changed = true
+		}
+		if !changed {
+			return ErrNothingChanged
 		}
-	}
-	if ticket == nil {
 		return nil
+	})
+}
+
+// inRankOrder returns the ids of the tickets that exist ordered by their rank
+func inRankOrder(tickets []Ticket, ids []TicketId) []TicketId {
+	var ordered []TicketId
+	for _, ticket := range tickets {
+		if slices.Contains(ids, ticket.ID) {
+			ordered = append(ordered, ticket.ID)
+		}
 	}
+	return ordered
+}
 
-	var newStatus Status
-	switch ticket.Status {
-	case Todo:
+func moveTicket(tx database.Querier, tickets []Ticket, id TicketId, status Status, position Position) error {
+	index := indexOfTicket(tickets, id)
+	if index < 0 {
+		return ErrNothingChanged
+	}
+	statusChanged := tickets[index].Status != status
+	if statusChanged {
+		err := tx.UpdateStatus(context.Background(), database.UpdateStatusParams{
+			ID:     id.number,
+			Status: status.String(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// slot is the index in all tickets before which the ticket is placed
+	slot := -1
+	switch position.kind {
+	case top, bottom:
+		for i, ticket := range tickets {
+			if ticket.Status != status || ticket.ID == id {
+				continue
+			}
+			if position.kind == top {
+				slot = i
+				break
+			}
+			slot = i + 1
+		}
+	case after, before:
+		other := indexOfTicket(tickets, position.ticket)
+		if other < 0 || tickets[other].Status != status {
+			return fmt.Errorf("%s is not in %s", position.ticket, status.ColumnTitle())
+		}
+		slot = other
+		if position.kind == after {
+			slot = other + 1
+		}
+	}
+	if slot < 0 || slot == index || slot == index+1 {
+		// The column is empty or the ticket already is at the position
+		if statusChanged {
+			return nil
+		}
+		return ErrNothingChanged
+	}
+	err := rankTicket(tx, tickets, index, slot)
+	if errors.Is(err, ErrNothingChanged) && statusChanged {
 		return nil
-	case InProgress:
-		newStatus = Todo
-	case Done:
-		newStatus = InProgress
-	default:
-		// assert amount of statusses didnt change
-		var _ = [3]any{}[NumberOfStatusses-1]
-		panic("unreachable")
 	}
+	return err
+}
 
-	return s.UpdateStatus(id, newStatus)
+func (s *store) DeleteTicket(id TicketId) tea.Cmd {
+	return s.DeleteTickets([]TicketId{id})
 }
 
-func (s *store) MoveToNextStatus(id TicketId) tea.Cmd {
-	var ticket *Ticket
-	for i, t := range s.tickets {
-		if t.ID == id {
-			ticket = &s.tickets[i]
+func (s *store) DeleteTickets(ids []TicketId) tea.Cmd {
+	return s.mutate("Failed to delete tickets", func(tx database.Querier, _ []Ticket) error {
+		for _, id := range ids {
+			if err := tx.DeleteTicket(context.Background(), id.number); err != nil {
+				return err
+			}
 		}
-	}
-	if ticket == nil {
 		return nil
-	}
+	})
+}
 
-	var newStatus Status
-	switch ticket.Status {
-	case Todo:
-		newStatus = InProgress
-	case InProgress:
-		newStatus = Done
-	case Done:
+func (s *store) ArchiveTickets(ids []TicketId) tea.Cmd {
+	return s.mutate("Failed to archive tickets", func(tx database.Querier, _ []Ticket) error {
+		for _, id := range ids {
+			if err := tx.ArchiveTicket(context.Background(), id.number); err != nil {
+				return err
+			}
+		}
 		return nil
-	default:
-		// assert amount of statusses didnt change
-		var _ = [3]any{}[NumberOfStatusses-1]
-		panic("unreachable")
+	})
+}
+
+func (s *store) LoadArchived() tea.Msg {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	tickets, err := s.loadArchived()
+	if err != nil {
+		return messages.ErrorMsg{
+			Err:          err,
+			FriendlyText: "Failed to load archived tickets",
+			Retry:        s.LoadArchived,
+		}
 	}
+	return ArchivedTicketsMsg{Tickets: tickets}
+}
 
-	return s.UpdateStatus(id, newStatus)
+func (s *store) loadArchived() ([]Ticket, error) {
+	rows, err := s.db.GetArchivedTickets(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return withLabels(s.db, rows)
 }
 
-func (s *store) DeleteTicket(id TicketId) tea.Cmd {
-	return func() tea.Msg {
-		err := s.db.DeleteTicket(context.Background(), id.number)
-		if err != nil {
-			return messages.CriticalFailureMsg{
-				Err:          err,
-				FriendlyText: "Failed to delete ticket",
+func (s *store) UnarchiveTickets(ids []TicketId) tea.Cmd {
+	return s.mutate("Failed to unarchive tickets", func(tx database.Querier, _ []Ticket) error {
+		for _, id := range ids {
+			changed, err := tx.UnarchiveTicket(context.Background(), id.number)
+			if err != nil {
+				return err
+			}
+			if changed == 0 {
+				return fmt.Errorf("%s is not archived", id)
+			}
+		}
+		return nil
+	})
+}
+
+func (s *store) AddLabel(ids []TicketId, label TicketLabel) tea.Cmd {
+	return s.mutate("Failed to add label", func(tx database.Querier, _ []Ticket) error {
+		for _, id := range ids {
+			err := tx.AddTicketLabel(context.Background(), database.AddTicketLabelParams{
+				TicketID: id.number,
+				Label:    string(label),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *store) RemoveLabel(ids []TicketId, label TicketLabel) tea.Cmd {
+	return s.mutate("Failed to remove label", func(tx database.Querier, _ []Ticket) error {
+		for _, id := range ids {
+			err := tx.RemoveTicketLabel(context.Background(), database.RemoveTicketLabelParams{
+				TicketID: id.number,
+				Label:    string(label),
+			})
+			if err != nil {
+				return err
 			}
 		}
-		s.tickets = slices.DeleteFunc(s.tickets, func(ticket Ticket) bool {
-			return ticket.ID == id
+		return nil
+	})
+}
+
+func (s *store) Import(tickets []Ticket) tea.Cmd {
+	var cmd tea.Cmd
+	cmd = func() tea.Msg {
+		var created []TicketId
+		msg := s.mutate("Failed to import tickets", func(tx database.Querier, _ []Ticket) error {
+			created = nil
+			for _, ticket := range tickets {
+				id, err := importTicket(tx, ticket)
+				if err != nil {
+					return err
+				}
+				created = append(created, id)
+			}
+			return nil
+		})()
+		switch msg := msg.(type) {
+		case TicketsUpdatedMsg:
+			msg.Created = created
+			return msg
+		case messages.ErrorMsg:
+			msg.Retry = cmd
+			return msg
+		}
+		return msg
+	}
+	return cmd
+}
+
+// importTicket adds the ticket, returning the id it got
+func importTicket(db database.Querier, ticket Ticket) (TicketId, error) {
+	ctx := context.Background()
+	row, err := db.AddTicket(ctx, database.AddTicketParams{
+		Title: string(ticket.Title),
+		Description: sql.NullString{
+			String: string(ticket.Description),
+			Valid:  ticket.Description != "",
+		},
+	})
+	if err != nil {
+		return TicketId{}, fmt.Errorf("failed to add ticket %q: %w", ticket.Title, err)
+	}
+
+	err = db.UpdateStatus(ctx, database.UpdateStatusParams{
+		ID:     row.ID,
+		Status: ticket.Status.String(),
+	})
+	if err != nil {
+		return TicketId{}, fmt.Errorf("failed to set status of ticket %q: %w", ticket.Title, err)
+	}
+
+	for _, label := range ticket.Labels {
+		err = db.AddTicketLabel(ctx, database.AddTicketLabelParams{
+			TicketID: row.ID,
+			Label:    string(label),
 		})
-		return TicketsUpdatedMsg{s.tickets}
+		if err != nil {
+			return TicketId{}, fmt.Errorf("failed to add label %q to ticket %q: %w", label, ticket.Title, err)
+		}
 	}
+	return TicketId{row.ID}, nil
 }
@@ -0,0 +1,118 @@
+package ticket
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Enforcement is what happens when moving tickets would exceed the limit of a column
+type Enforcement int
+
+const (
+	// Warn only colors the header of the column
+	Warn Enforcement = iota
+	// Confirm asks before moving the tickets
+	Confirm
+	// Block refuses to move the tickets, also when they are moved by the api
+	Block
+)
+
+func (e Enforcement) String() string {
+	switch e {
+	case Confirm:
+		return "confirm"
+	case Block:
+		return "block"
+	default:
+		return "warn"
+	}
+}
+
+// WipLimits are the maximum amount of tickets per status,
+// statusses without a limit can have any amount of tickets
+type WipLimits struct {
+	Limits      map[Status]int
+	Enforcement Enforcement
+}
+
+// ResolveWipLimits parses the limits of the config, keyed by the name of the status,
+// and how they are enforced, empty means they only warn
+func ResolveWipLimits(limits map[string]int, enforcement string) (WipLimits, error) {
+	resolved := WipLimits{Limits: map[Status]int{}}
+	for name, limit := range limits {
+		status, err := ParseStatus(name)
+		if err != nil {
+			return resolved, fmt.Errorf("invalid wip limit: %w", err)
+		}
+		if limit <= 0 {
+			return resolved, fmt.Errorf("invalid wip limit for %s: %d, expected a positive number", name, limit)
+		}
+		resolved.Limits[status] = limit
+	}
+	if enforcement == "" {
+		return resolved, nil
+	}
+	for e := range Block + 1 {
+		if strings.EqualFold(enforcement, e.String()) {
+			resolved.Enforcement = e
+			return resolved, nil
+		}
+	}
+	return resolved, fmt.Errorf("invalid wip limit enforcement %q, expected warn, confirm or block", enforcement)
+}
+
+var wipLimits = WipLimits{}
+
+// GetWipLimits returns the limits the board and the store enforce
+func GetWipLimits() WipLimits {
+	return wipLimits
+}
+
+// SetWipLimits replaces the limits the board and the store enforce,
+// it should be called before the store is used
+func SetWipLimits(limits WipLimits) {
+	wipLimits = limits
+}
+
+// WipLimitError is the result of a move that would exceed the limit of a status
+type WipLimitError struct {
+	Status Status
+	Count  int
+	Limit  int
+	Moving int
+}
+
+func (e *WipLimitError) Error() string {
+	return fmt.Sprintf("%s has %d of %d tickets, moving %d more would exceed the wip limit", e.Status.ColumnTitle(), e.Count, e.Limit, e.Moving)
+}
+
+// Check returns a WipLimitError when moving the tickets with the ids to the status
+// would exceed its limit, regardless of how the limits are enforced
+func (l WipLimits) Check(tickets []Ticket, ids []TicketId, status Status) error {
+	limit, ok := l.Limits[status]
+	if !ok {
+		return nil
+	}
+	count, moving := 0, 0
+	for _, t := range tickets {
+		switch {
+		case t.Status == status:
+			count++
+		case slices.Contains(ids, t.ID):
+			moving++
+		}
+	}
+	if moving == 0 || count+moving <= limit {
+		return nil
+	}
+	return &WipLimitError{Status: status, Count: count, Limit: limit, Moving: moving}
+}
+
+// enforceWipLimit refuses a move in a mutation when the limits block moves that exceed them
+func enforceWipLimit(tickets []Ticket, ids []TicketId, status Status) error {
+	if wipLimits.Enforcement != Block {
+		return nil
+	}
+	return wipLimits.Check(tickets, ids, status)
+}
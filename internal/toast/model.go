@@ -0,0 +1,80 @@
+// Package toast shows recoverable errors on top of the board
+package toast
+
+import (
+	"log/slog"
+
+	"github.com/Kavantix/kantui/internal/keys"
+	"github.com/Kavantix/kantui/internal/messages"
+	"github.com/Kavantix/kantui/internal/theme"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type Model struct {
+	err *messages.ErrorMsg
+}
+
+func (m Model) Visible() bool {
+	return m.err != nil
+}
+
+// Show replaces the current toast with the error
+func (m Model) Show(msg messages.ErrorMsg) Model {
+	slog.Error(msg.FriendlyText, slog.String("error", msg.Err.Error()))
+	m.err = &msg
+	return m
+}
+
+func (m Model) Dismiss() Model {
+	m.err = nil
+	return m
+}
+
+// Retry dismisses the toast and runs the failed operation again
+func (m Model) Retry() (Model, tea.Cmd) {
+	if m.err == nil || m.err.Retry == nil {
+		return m, nil
+	}
+	retry := m.err.Retry
+	m.err = nil
+	return m, retry
+}
+
+// LastError returns the error of the toast that is currently shown
+func (m Model) LastError() *messages.ErrorMsg {
+	return m.err
+}
+
+func (m Model) View(maxWidth int) string {
+	if m.err == nil {
+		return ""
+	}
+	theme := theme.Get()
+	toastStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Error).
+		Padding(0, 1)
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.Error).
+		Bold(true)
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.Muted)
+	frameWidth, _ := toastStyle.GetFrameSize()
+	width := min(maxWidth, 60) - frameWidth
+	keyMap := keys.Get().Board
+	hint := keyMap.DismissError.Help().Key + " " + keyMap.DismissError.Help().Desc
+	if m.err.Retry != nil {
+		hint = keyMap.RetryError.Help().Key + " " + keyMap.RetryError.Help().Desc + " · " + hint
+	}
+	title := m.err.FriendlyText
+	if title == "" {
+		title = "Failed"
+	}
+	return toastStyle.Render(lipgloss.JoinVertical(
+		lipgloss.Left,
+		titleStyle.Width(width).Render(title),
+		lipgloss.NewStyle().Width(width).Render(m.err.Err.Error()),
+		hintStyle.Render(hint),
+	))
+}
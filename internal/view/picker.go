@@ -0,0 +1,134 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Kavantix/kantui/internal/keys"
+	"github.com/Kavantix/kantui/internal/messages"
+	"github.com/Kavantix/kantui/internal/overlay"
+	"github.com/Kavantix/kantui/internal/statusbar"
+	"github.com/Kavantix/kantui/internal/theme"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Picker lists the saved views to switch to or delete
+type Picker struct {
+	views   []View
+	store   Store
+	current string
+	cursor  int
+}
+
+// assert
+var _ overlay.ModalModel = Picker{}
+var _ statusbar.Hinter = Picker{}
+
+// ShowPicker opens the list of views with the cursor on the current view
+func ShowPicker(views []View, current string, store Store) tea.Cmd {
+	return func() tea.Msg {
+		m := Picker{views: views, store: store, current: current}
+		for i, view := range views {
+			if view.Name == current {
+				m.cursor = i
+			}
+		}
+		return m
+	}
+}
+
+func (m Picker) Init() tea.Cmd {
+	return nil
+}
+
+func (m Picker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		keyMap := keys.Get().Views
+		switch {
+		case key.Matches(msg, keyMap.Up):
+			m.cursor = max(0, m.cursor-1)
+		case key.Matches(msg, keyMap.Down):
+			m.cursor = max(0, min(len(m.views)-1, m.cursor+1))
+		case key.Matches(msg, keyMap.Apply):
+			if len(m.views) == 0 {
+				return m, nil
+			}
+			view := m.views[m.cursor]
+			return m, tea.Batch(
+				messages.CloseModal,
+				func() tea.Msg { return ApplyMsg{View: view} },
+			)
+		case key.Matches(msg, keyMap.Delete):
+			if len(m.views) == 0 {
+				return m, nil
+			}
+			name := m.views[m.cursor].Name
+			// The board receives the updated views, the picker removes it right away
+			m.views = append(m.views[:m.cursor:m.cursor], m.views[m.cursor+1:]...)
+			m.cursor = max(0, min(len(m.views)-1, m.cursor))
+			return m, m.store.Delete(name)
+		case key.Matches(msg, keyMap.Close):
+			return m, messages.CloseModal
+		}
+	}
+	return m, nil
+}
+
+// KeyHints implements statusbar.Hinter.
+func (m Picker) KeyHints() []key.Binding {
+	keyMap := keys.Get().Views
+	return []key.Binding{keyMap.Up, keyMap.Down, keyMap.Apply, keyMap.Delete, keyMap.Close}
+}
+
+// Size implements overlay.ModalModel.
+func (m Picker) Size() (width int, height int) {
+	content := m.View()
+	return lipgloss.Width(content), lipgloss.Height(content)
+}
+
+func viewStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Get().Accent).
+		Padding(1, 2)
+}
+
+func (m Picker) View() string {
+	theme := theme.Get()
+	width := 56
+	var lines []string
+	if len(m.views) == 0 {
+		save := keys.Get().Board.SaveView.Help().Key
+		lines = append(lines, lipgloss.NewStyle().
+			Foreground(theme.Muted).
+			Render("No saved views, press "+save+" on the board to save the current one"))
+	}
+	numberStyle := lipgloss.NewStyle().Foreground(theme.Highlight)
+	for i, view := range m.views {
+		prefix := "  "
+		nameStyle := lipgloss.NewStyle().Foreground(theme.Text)
+		if i == m.cursor {
+			prefix = "▸ "
+			nameStyle = nameStyle.Foreground(theme.Selected).Bold(true)
+		}
+		number := "  "
+		if i < 9 {
+			number = fmt.Sprintf("%d ", i+1)
+		}
+		name := view.Name
+		if view.Name == m.current {
+			name += " ●"
+		}
+		line := prefix + numberStyle.Render(number) + nameStyle.Render(name)
+		if view.Query != "" {
+			line += lipgloss.NewStyle().Foreground(theme.Muted).Render("  " + view.Query)
+		}
+		lines = append(lines, ansi.Truncate(line, width, "…"))
+	}
+	content := lipgloss.NewStyle().Width(width).Render(strings.Join(lines, "\n"))
+	return overlay.Place(4, 0, "Views", viewStyle().Render(content), false)
+}
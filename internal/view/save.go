@@ -0,0 +1,87 @@
+package view
+
+import (
+	"strings"
+
+	"github.com/Kavantix/kantui/internal/keys"
+	"github.com/Kavantix/kantui/internal/messages"
+	"github.com/Kavantix/kantui/internal/overlay"
+	"github.com/Kavantix/kantui/internal/statusbar"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Save asks for the name to save the current query and layout under
+type Save struct {
+	view  View
+	store Store
+	input textinput.Model
+}
+
+// assert
+var _ overlay.ModalModel = Save{}
+var _ statusbar.Hinter = Save{}
+
+// ShowSave asks for the name of the view,
+// suggesting the name of the view it was based on to update it
+func ShowSave(view View, store Store) tea.Cmd {
+	return func() tea.Msg {
+		input := textinput.New()
+		input.Placeholder = "My bugs"
+		input.Prompt = "Name: "
+		input.Width = 40
+		input.SetValue(view.Name)
+		input.Focus()
+		return Save{view: view, store: store, input: input}
+	}
+}
+
+func (m Save) Init() tea.Cmd {
+	return nil
+}
+
+func (m Save) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		keyMap := keys.Get().Views
+		switch {
+		case key.Matches(msg, keyMap.Save):
+			view := m.view
+			view.Name = strings.TrimSpace(m.input.Value())
+			if view.Name == "" {
+				return m, nil
+			}
+			return m, tea.Batch(
+				m.store.Save(view),
+				messages.CloseModal,
+				func() tea.Msg { return ApplyMsg{View: view} },
+			)
+		case key.Matches(msg, keyMap.Cancel):
+			return m, messages.CloseModal
+		}
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// KeyHints implements statusbar.Hinter.
+func (m Save) KeyHints() []key.Binding {
+	keyMap := keys.Get().Views
+	return []key.Binding{keyMap.Save, keyMap.Cancel}
+}
+
+// Size implements overlay.ModalModel.
+func (m Save) Size() (width int, height int) {
+	content := m.View()
+	return lipgloss.Width(content), lipgloss.Height(content)
+}
+
+func (m Save) View() string {
+	content := lipgloss.NewStyle().
+		Width(m.input.Width + lipgloss.Width(m.input.Prompt) + 1).
+		Render(m.input.View())
+	return overlay.Place(4, 0, "Save view", viewStyle().Render(content), false)
+}
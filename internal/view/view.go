@@ -0,0 +1,137 @@
+// Package view saves the query and layout of the board as named views
+package view
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Kavantix/kantui/internal/database"
+	"github.com/Kavantix/kantui/internal/messages"
+	"github.com/Kavantix/kantui/internal/ticket"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// View is a named query and layout of the board,
+// the zero value shows all tickets in all columns
+type View struct {
+	Name    string
+	Query   string
+	Columns map[ticket.Status]Column
+}
+
+// Column is the layout of a single column in a view
+type Column struct {
+	// Sort is the name of the sort order, empty for the rank
+	Sort      string `json:"sort,omitempty"`
+	Hidden    bool   `json:"hidden,omitempty"`
+	Collapsed bool   `json:"collapsed,omitempty"`
+}
+
+// UpdatedMsg holds all saved views in the order they were created
+type UpdatedMsg struct {
+	Views []View
+}
+
+// ApplyMsg is sent when a view is chosen, the board takes over its query and layout
+type ApplyMsg struct {
+	View View
+}
+
+// Store persists the views in the database of the board
+type Store interface {
+	Load() tea.Msg
+	// Save adds the view or replaces the view with the same name
+	Save(view View) tea.Cmd
+	Delete(name string) tea.Cmd
+}
+
+type store struct {
+	db database.Querier
+	// tickets runs the writes, so they are not seen as changes made by another process
+	tickets ticket.Store
+}
+
+func NewStore(db database.Querier, tickets ticket.Store) Store {
+	return &store{db: db, tickets: tickets}
+}
+
+func (s *store) Load() tea.Msg {
+	rows, err := s.db.GetViews(context.Background())
+	if err != nil {
+		return messages.ErrorMsg{
+			Err:          err,
+			FriendlyText: "Failed to load views",
+			Retry:        s.Load,
+		}
+	}
+	views := make([]View, 0, len(rows))
+	for _, row := range rows {
+		layout := map[string]Column{}
+		if err := json.Unmarshal([]byte(row.Layout), &layout); err != nil {
+			return messages.ErrorMsg{
+				Err:          fmt.Errorf("invalid layout of view %q: %w", row.Name, err),
+				FriendlyText: "Failed to load views",
+				Retry:        s.Load,
+			}
+		}
+		view := View{Name: row.Name, Query: row.Query, Columns: map[ticket.Status]Column{}}
+		for name, column := range layout {
+			// Columns that no longer exist are left out
+			if status, err := ticket.ParseStatus(name); err == nil {
+				view.Columns[status] = column
+			}
+		}
+		views = append(views, view)
+	}
+	return UpdatedMsg{Views: views}
+}
+
+func (s *store) Save(view View) tea.Cmd {
+	var cmd tea.Cmd
+	cmd = func() tea.Msg {
+		layout := map[string]Column{}
+		for status, column := range view.Columns {
+			if column != (Column{}) {
+				layout[status.String()] = column
+			}
+		}
+		encoded, err := json.Marshal(layout)
+		if err == nil {
+			err = s.tickets.Write(func(tx database.Querier) error {
+				return tx.SaveView(context.Background(), database.SaveViewParams{
+					Name:   view.Name,
+					Query:  view.Query,
+					Layout: string(encoded),
+				})
+			})
+		}
+		if err != nil {
+			return messages.ErrorMsg{
+				Err:          err,
+				FriendlyText: "Failed to save view",
+				Retry:        cmd,
+			}
+		}
+		return s.Load()
+	}
+	return cmd
+}
+
+func (s *store) Delete(name string) tea.Cmd {
+	var cmd tea.Cmd
+	cmd = func() tea.Msg {
+		err := s.tickets.Write(func(tx database.Querier) error {
+			return tx.DeleteView(context.Background(), name)
+		})
+		if err != nil {
+			return messages.ErrorMsg{
+				Err:          err,
+				FriendlyText: "Failed to delete view",
+				Retry:        cmd,
+			}
+		}
+		return s.Load()
+	}
+	return cmd
+}
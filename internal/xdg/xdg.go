@@ -0,0 +1,29 @@
+// Package xdg resolves the directories of the XDG base directory specification
+package xdg
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// ConfigHome returns $XDG_CONFIG_HOME, defaulting to ~/.config
+func ConfigHome() string {
+	return dir("XDG_CONFIG_HOME", ".config")
+}
+
+// DataHome returns $XDG_DATA_HOME, defaulting to ~/.local/share
+func DataHome() string {
+	return dir("XDG_DATA_HOME", filepath.Join(".local", "share"))
+}
+
+func dir(env, fallback string) string {
+	// Relative paths are invalid according to the specification
+	if value := os.Getenv(env); filepath.IsAbs(value) {
+		return value
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fallback
+	}
+	return filepath.Join(home, fallback)
+}